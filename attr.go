@@ -7,6 +7,8 @@ import "strings"
 // https://developer.mozilla.org/en-US/docs/Web/API/Attr
 // https://dom.spec.whatwg.org/#attr
 type Attr interface {
+	/* Private */
+	setOwnerElement(Element)
 	/* EMBEDDED INTERFACE */
 	Node
 	/* GETTERS & SETTERS (props) */
@@ -27,12 +29,18 @@ type attr struct {
 }
 
 func createAttribute(name string) Attr {
-	return &attr{
-		node:         &node{},
+	a := &attr{
 		name:         strings.ToLower(name),
 		ownerElement: nil,
 		value:        "",
 	}
+	a.node = embedNode(a)
+
+	return a
+}
+
+func (a *attr) setOwnerElement(owner Element) {
+	a.ownerElement = owner
 }
 
 /*****************************************************
@@ -52,6 +60,18 @@ func (a *attr) NodeType() NodeType {
 	return AttributeNode
 }
 
+// TextContent return the attribute value.
+// https://dom.spec.whatwg.org/#dom-node-textcontent
+func (a *attr) TextContent() string {
+	return a.Value()
+}
+
+// SetTextContent set the attribute value.
+// https://dom.spec.whatwg.org/#dom-node-textcontent
+func (a *attr) SetTextContent(content string) {
+	a.SetValue(content)
+}
+
 /* - Methods */
 
 // CloneNode return a clone of the Attr
@@ -118,5 +138,10 @@ func (a *attr) Value() string {
 
 // SetValue set the attribute value of an element
 func (a *attr) SetValue(value string) {
+	oldValue := a.value
 	a.value = value
+
+	if a.ownerElement != nil {
+		a.ownerElement.attributeChanged(a.name, &oldValue, &value)
+	}
 }
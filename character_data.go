@@ -1,6 +1,6 @@
 package gom
 
-import "strings"
+import "unicode/utf8"
 
 // The CharacterData abstract interface represents
 // a Node object that contains characters.
@@ -9,6 +9,7 @@ import "strings"
 type CharacterData interface {
 	/* EMBEDDED INTERFACE */
 	Node
+	ChildNode
 	NonDocumentTypeChildNode
 	/* GETTERS & SETTERS (props) */
 	Data() string
@@ -26,10 +27,40 @@ var _ CharacterData = &characterData{}
 
 type characterData struct {
 	*node
+	*childNode
 	*nonDocumentTypeChildNode
 	data string
 }
 
+// init initialize the character data embedded by the
+// given self node.
+func (cd *characterData) init(self CharacterData, data string) {
+	cd.node = embedNode(self)
+	cd.childNode = newChildNode(self)
+	cd.nonDocumentTypeChildNode = newNonDocumentTypeChildNode(self)
+	cd.data = data
+}
+
+/*****************************************************
+ **************** Embedded interface *****************
+ *****************************************************/
+// ANCHOR Embedded interface
+
+/* Node */
+/* - Props */
+
+// TextContent return the data of this object.
+// https://dom.spec.whatwg.org/#dom-node-textcontent
+func (cd *characterData) TextContent() string {
+	return cd.Data()
+}
+
+// SetTextContent set the data of this object.
+// https://dom.spec.whatwg.org/#dom-node-textcontent
+func (cd *characterData) SetTextContent(content string) {
+	cd.SetData(content)
+}
+
 /*****************************************************
  **************** Getters & Setters ******************
  *****************************************************/
@@ -41,17 +72,17 @@ func (cd *characterData) Data() string {
 	return cd.data
 }
 
-// Length return the size of the string contained in
-// CharacterData.data.
+// Length return the number of characters contained
+// in CharacterData.data.
 // https://dom.spec.whatwg.org/#dom-characterdata-length
 func (cd *characterData) Length() int {
-	return len(cd.data)
+	return utf8.RuneCountInString(cd.data)
 }
 
 // SetData set the textual data conatined in this object.
 // https://dom.spec.whatwg.org/#dom-characterdata-data
 func (cd *characterData) SetData(data string) {
-	cd.data = data
+	cd.replaceData(0, cd.Length(), data)
 }
 
 /*****************************************************
@@ -59,49 +90,96 @@ func (cd *characterData) SetData(data string) {
  *****************************************************/
 // ANCHOR Methods
 
-func (cd *characterData) AppendData(data string) string {
-	var b strings.Builder
-
-	b.WriteString(cd.data)
-	b.WriteString(data)
+// replaceData replace count characters, starting at the
+// offset, with data, queue a characterData record and
+// update the live ranges. The offset and count are
+// clamped to the data length.
+// https://dom.spec.whatwg.org/#concept-cd-replace
+func (cd *characterData) replaceData(offset, count int, data string) {
+	r := []rune(cd.data)
+	length := len(r)
+
+	if offset > length {
+		offset = length
+	}
+	if count < 0 || count > length-offset {
+		count = length - offset
+	}
+
+	self := cd.node.self
+	oldValue := cd.data
+	queueMutationRecord(&mutationRecord{
+		recordType: MutationCharacterData,
+		target:     self,
+		oldValue:   &oldValue,
+	})
+
+	cd.data = string(r[:offset]) + data + string(r[offset+count:])
+
+	for lr := range liveRangesOf(self) {
+		lr.dataReplaced(self, offset, count, utf8.RuneCountInString(data))
+	}
+}
 
-	cd.data = b.String()
+// AppendData add the string to the end of the data and
+// return the modified data.
+// https://dom.spec.whatwg.org/#dom-characterdata-appenddata
+func (cd *characterData) AppendData(data string) string {
+	cd.replaceData(cd.Length(), 0, data)
 
 	return cd.data
 }
 
+// DeleteData remove the specified amount of characters,
+// starting at the specified offset, and return the
+// modified data.
+// https://dom.spec.whatwg.org/#dom-characterdata-deletedata
 func (cd *characterData) DeleteData(offset, count uint) string {
-	r := []rune(cd.data)
-	r = append(r[offset:], r[offset+count:]...)
-
-	cd.data = string(r)
+	cd.replaceData(clampUint(offset), clampUint(count), "")
 
 	return cd.data
 }
 
+// InsertData insert the string at the given offset and
+// return the modified data.
+// https://dom.spec.whatwg.org/#dom-characterdata-insertdata
 func (cd *characterData) InsertData(offset uint, data string) string {
-	r := []rune(cd.data)
-	d := []rune(data)
-
-	r = append(r[offset:], d...)
-	r = append(r, r[offset+1:]...)
-
-	cd.data = string(r)
+	cd.replaceData(clampUint(offset), 0, data)
 
 	return cd.data
 }
 
 // ReplaceData replace the specified amount of characters,
 // starting at the specified offset, with the specified
-// string and return the modified string.
-// https://dom.spec.whatwg.org/#concept-cd-replace
+// string.
+// https://dom.spec.whatwg.org/#dom-characterdata-replacedata
 func (cd *characterData) ReplaceData(offset, count uint, data string) {
-	length := cd.Length()
-
+	cd.replaceData(clampUint(offset), clampUint(count), data)
 }
 
+// SubstringData return the specified amount of characters
+// starting at the specified offset.
+// https://dom.spec.whatwg.org/#dom-characterdata-substringdata
 func (cd *characterData) SubstringData(offset, count uint) string {
 	r := []rune(cd.data)
+	length := uint(len(r))
+
+	if offset > length {
+		offset = length
+	}
+	if offset+count > length || offset+count < offset {
+		count = length - offset
+	}
 
 	return string(r[offset : offset+count])
 }
+
+// clampUint convert the uint to int, clamping it to
+// the maximum int value.
+func clampUint(u uint) int {
+	if maxInt := ^uint(0) >> 1; u > maxInt {
+		return int(maxInt)
+	}
+
+	return int(u)
+}
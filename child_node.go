@@ -0,0 +1,177 @@
+package gom
+
+import e "github.com/negrel/gom/exception"
+
+// ChildNode interface contains methods that are
+// particular to Node objects that can have a parent.
+// The nodes arguments are either Node or string, the
+// strings are converted into Text nodes.
+// https://developer.mozilla.org/en-US/docs/Web/API/ChildNode
+// https://dom.spec.whatwg.org/#interface-childnode
+type ChildNode interface {
+	/* METHODS */
+	After(nodes ...interface{}) e.Exception
+	Before(nodes ...interface{}) e.Exception
+	Remove()
+	ReplaceWith(nodes ...interface{}) e.Exception
+}
+
+var _ ChildNode = &childNode{}
+
+type childNode struct {
+	self Node
+}
+
+func newChildNode(self Node) *childNode {
+	return &childNode{
+		self: self,
+	}
+}
+
+// convertNodesIntoNode return the node to insert in place
+// of the given nodes or strings. Strings are converted into
+// Text nodes of the document and multiple nodes are
+// gathered in a DocumentFragment. A TypeError exception
+// is returned for any other argument type.
+// https://dom.spec.whatwg.org/#converting-nodes-into-a-node
+func convertNodesIntoNode(doc Document, nodes []interface{}) (Node, e.Exception) {
+	converted := make([]Node, 0, len(nodes))
+
+	for _, node := range nodes {
+		switch n := node.(type) {
+		case Node:
+			converted = append(converted, n)
+
+		case string:
+			var text Text
+			if doc != nil {
+				text = doc.CreateTextNode(n)
+			} else {
+				text = createTextNode(n)
+			}
+			converted = append(converted, text)
+
+		default:
+			return nil, e.TypeError("Arguments must be Node or string, got %T.", node)
+		}
+	}
+
+	if len(converted) == 1 {
+		return converted[0], nil
+	}
+
+	fragment := createDocumentFragment()
+	fragment.SetOwnerDocument(doc)
+	for _, node := range converted {
+		if _, err := fragment.AppendChild(node); err != nil {
+			return nil, err
+		}
+	}
+
+	return fragment, nil
+}
+
+// containsNode report whether the nodes or strings
+// contains the given node.
+func containsNode(nodes []interface{}, node Node) bool {
+	for _, n := range nodes {
+		if n, isNode := n.(Node); isNode && n.IsSameNode(node) {
+			return true
+		}
+	}
+
+	return false
+}
+
+/*****************************************************
+ ********************* Methods ***********************
+ *****************************************************/
+// ANCHOR Methods
+
+// After inserts a set of Node or string objects in the
+// children list of this node parent, just after this node.
+// https://developer.mozilla.org/en-US/docs/Web/API/ChildNode/after
+func (cn *childNode) After(nodes ...interface{}) e.Exception {
+	parent := cn.self.ParentNode()
+	if parent == nil {
+		return nil
+	}
+
+	// First following sibling not in nodes
+	viableNextSibling := cn.self.NextSibling()
+	for viableNextSibling != nil && containsNode(nodes, viableNextSibling) {
+		viableNextSibling = viableNextSibling.NextSibling()
+	}
+
+	node, err := convertNodesIntoNode(nodeDocument(cn.self), nodes)
+	if err != nil {
+		return err
+	}
+
+	_, err = parent.InsertBefore(node, viableNextSibling)
+	return err
+}
+
+// Before inserts a set of Node or string objects in the
+// children list of this node parent, just before this node.
+// https://developer.mozilla.org/en-US/docs/Web/API/ChildNode/before
+func (cn *childNode) Before(nodes ...interface{}) e.Exception {
+	parent := cn.self.ParentNode()
+	if parent == nil {
+		return nil
+	}
+
+	// First preceding sibling not in nodes
+	viablePreviousSibling := cn.self.PreviousSibling()
+	for viablePreviousSibling != nil && containsNode(nodes, viablePreviousSibling) {
+		viablePreviousSibling = viablePreviousSibling.PreviousSibling()
+	}
+
+	node, err := convertNodesIntoNode(nodeDocument(cn.self), nodes)
+	if err != nil {
+		return err
+	}
+
+	reference := parent.FirstChild()
+	if viablePreviousSibling != nil {
+		reference = viablePreviousSibling.NextSibling()
+	}
+
+	_, err = parent.InsertBefore(node, reference)
+	return err
+}
+
+// Remove removes this node from its parent children list.
+// https://developer.mozilla.org/en-US/docs/Web/API/ChildNode/remove
+func (cn *childNode) Remove() {
+	detach(cn.self)
+}
+
+// ReplaceWith replaces this node in the children list of
+// its parent with a set of Node or string objects.
+// https://developer.mozilla.org/en-US/docs/Web/API/ChildNode/replaceWith
+func (cn *childNode) ReplaceWith(nodes ...interface{}) e.Exception {
+	parent := cn.self.ParentNode()
+	if parent == nil {
+		return nil
+	}
+
+	// First following sibling not in nodes
+	viableNextSibling := cn.self.NextSibling()
+	for viableNextSibling != nil && containsNode(nodes, viableNextSibling) {
+		viableNextSibling = viableNextSibling.NextSibling()
+	}
+
+	node, err := convertNodesIntoNode(nodeDocument(cn.self), nodes)
+	if err != nil {
+		return err
+	}
+
+	// This node may have been moved in node
+	if isChildOf(cn.self, parent) {
+		return parent.ReplaceChild(node, cn.self)
+	}
+
+	_, err = parent.InsertBefore(node, viableNextSibling)
+	return err
+}
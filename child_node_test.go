@@ -0,0 +1,105 @@
+package gom
+
+import (
+	"testing"
+)
+
+func TestChildNodeBeforeAfter(t *testing.T) {
+	doc := parseTestDocument(t, `<div><b></b><i></i></div>`)
+	div := doc.DocumentElement()
+	b, i := div.FirstChild().(Element), div.LastChild().(Element)
+
+	if err := b.Before("x", doc.CreateElement("p")); err != nil {
+		t.Fatalf("Before must not return an error : %v", err)
+	}
+
+	if div.InnerGOML() != "x<p></p><b></b><i></i>" {
+		t.Logf("Nodes must be inserted before the node, got %v.", div.InnerGOML())
+		t.Fail()
+	}
+
+	if err := b.After(i, "y"); err != nil {
+		t.Fatalf("After must not return an error : %v", err)
+	}
+
+	if div.InnerGOML() != "x<p></p><b></b><i></i>y" {
+		t.Logf("Nodes must be inserted after the node, got %v.", div.InnerGOML())
+		t.Fail()
+	}
+
+	text := div.FirstChild()
+	if text.NodeType() != TextNode || text.OwnerDocument() != doc {
+		t.Log("Strings must be converted into Text nodes of the owner document.")
+		t.Fail()
+	}
+
+	// Siblings of the node can be part of the arguments
+	if err := i.Before(i, b); err != nil {
+		t.Fatalf("Before must not return an error : %v", err)
+	}
+
+	if div.InnerGOML() != "x<p></p><i></i><b></b>y" {
+		t.Logf("Preceding sibling in the arguments must be moved, got %v.", div.InnerGOML())
+		t.Fail()
+	}
+
+	if err := b.After(1); err == nil || err.Name() != "TypeError" {
+		t.Log("Invalid argument type must return a TypeError exception.")
+		t.Fail()
+	}
+}
+
+func TestChildNodeReplaceWith(t *testing.T) {
+	doc := parseTestDocument(t, `<div><b></b><i></i><u></u></div>`)
+	div := doc.DocumentElement()
+	b := div.FirstChild().(Element)
+	i := b.NextSibling().(Element)
+
+	if err := i.ReplaceWith("text", i); err != nil {
+		t.Fatalf("ReplaceWith must not return an error : %v", err)
+	}
+
+	if div.InnerGOML() != "<b></b>text<i></i><u></u>" {
+		t.Logf("Node must be replaceable by itself, got %v.", div.InnerGOML())
+		t.Fail()
+	}
+
+	if err := b.ReplaceWith(doc.CreateComment("c")); err != nil {
+		t.Fatalf("ReplaceWith must not return an error : %v", err)
+	}
+
+	if div.InnerGOML() != "<!--c-->text<i></i><u></u>" || b.ParentNode() != nil {
+		t.Logf("Node must be replaced, got %v.", div.InnerGOML())
+		t.Fail()
+	}
+}
+
+func TestChildNodeRemove(t *testing.T) {
+	doc := parseTestDocument(t, `<div><b></b>text</div>`)
+	div := doc.DocumentElement()
+	b := div.FirstChild().(Element)
+
+	b.Remove()
+	div.LastChild().(Text).Remove()
+
+	if div.HasChildNodes() || b.ParentNode() != nil {
+		t.Log("Remove must remove the node from its parent.")
+		t.Fail()
+	}
+
+	// No parent
+	b.Remove()
+	if err := b.Before("x"); err != nil {
+		t.Log("Before without parent must do nothing.")
+		t.Fail()
+	}
+
+	doctype := doc.DocType()
+	if doctype != nil {
+		doctype.Remove()
+		if doc.DocType() != nil {
+			t.Log("DocumentType must be removable.")
+			t.Fail()
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package gom
+
+// Comment interface represents textual notations
+// within markup.
+// https://developer.mozilla.org/en-US/docs/Web/API/Comment
+// https://dom.spec.whatwg.org/#interface-comment
+type Comment interface {
+	/* EMBEDDED INTERFACE */
+	CharacterData
+}
+
+var _ Comment = &comment{}
+var _ Node = &comment{}
+
+type comment struct {
+	*characterData
+}
+
+// createComment return a new Comment node.
+func createComment(data string) Comment {
+	c := &comment{&characterData{}}
+	c.init(c, data)
+
+	return c
+}
+
+/*****************************************************
+ **************** Embedded interface *****************
+ *****************************************************/
+// ANCHOR Embedded interface
+
+/* Node */
+/* - Props */
+
+// NodeName return "#comment".
+func (c *comment) NodeName() string {
+	return "#comment"
+}
+
+// NodeType return the "CommentNode" type.
+func (c *comment) NodeType() NodeType {
+	return CommentNode
+}
+
+/* - Methods */
+
+// CloneNode return a clone of the Comment.
+func (c *comment) CloneNode(_ bool) Node {
+	clone := createComment(c.data)
+	clone.SetOwnerDocument(c.document)
+
+	return clone
+}
+
+// IsEqualNode return wether or not two Comment are equal.
+func (c *comment) IsEqualNode(other Node) bool {
+	if other == nil || other.NodeType() != CommentNode {
+		return false
+	}
+
+	return c.Data() == other.(Comment).Data()
+}
@@ -0,0 +1,68 @@
+package gom
+
+// CustomEvent interface represents an event carrying
+// application data in its detail.
+// https://developer.mozilla.org/en-US/docs/Web/API/CustomEvent
+// https://dom.spec.whatwg.org/#interface-customevent
+type CustomEvent interface {
+	/* EMBEDDED INTERFACE */
+	Event
+	/* GETTERS & SETTERS (props) */
+	Detail() interface{}
+	/* METHODS */
+	InitCustomEvent(eventType string, bubbles, cancelable bool, detail interface{})
+}
+
+// CustomEventInit contains the options of NewCustomEvent.
+// https://dom.spec.whatwg.org/#dictdef-customeventinit
+type CustomEventInit struct {
+	EventInit
+	Detail interface{}
+}
+
+var _ CustomEvent = &customEvent{}
+
+type customEvent struct {
+	*event
+	detail interface{}
+}
+
+// NewCustomEvent return a new CustomEvent of the given
+// type.
+// https://developer.mozilla.org/en-US/docs/Web/API/CustomEvent/CustomEvent
+func NewCustomEvent(eventType string, init CustomEventInit) CustomEvent {
+	return &customEvent{
+		event:  newEvent(eventType, init.EventInit),
+		detail: init.Detail,
+	}
+}
+
+/*****************************************************
+ **************** Getters & Setters ******************
+ *****************************************************/
+// ANCHOR Getters & Setters
+
+// Detail return the data given when the event was
+// initialized.
+// https://developer.mozilla.org/en-US/docs/Web/API/CustomEvent/detail
+func (ce *customEvent) Detail() interface{} {
+	return ce.detail
+}
+
+/*****************************************************
+ ********************* Methods ***********************
+ *****************************************************/
+// ANCHOR Methods
+
+// InitCustomEvent initialize a CustomEvent created with
+// Document.CreateEvent. It is a no-op if the event is
+// being dispatched.
+// https://developer.mozilla.org/en-US/docs/Web/API/CustomEvent/initCustomEvent
+func (ce *customEvent) InitCustomEvent(eventType string, bubbles, cancelable bool, detail interface{}) {
+	if ce.dispatching {
+		return
+	}
+
+	ce.initEvent(eventType, bubbles, cancelable)
+	ce.detail = detail
+}
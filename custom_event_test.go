@@ -0,0 +1,55 @@
+package gom
+
+import (
+	"testing"
+)
+
+type itemSelected struct {
+	id int
+}
+
+func TestCustomEvent(t *testing.T) {
+	doc := parseTestDocument(t, `<div><ul><li>item</li></ul></div>`)
+	div := doc.DocumentElement()
+	li := div.FirstElementChild().FirstElementChild()
+
+	var detail interface{}
+	div.AddEventListener("item-selected", NewEventListener(func(event Event) {
+		detail = event.(CustomEvent).Detail()
+	}), AddEventListenerOptions{})
+
+	event := NewCustomEvent("item-selected", CustomEventInit{
+		EventInit: EventInit{Bubbles: true},
+		Detail:    itemSelected{id: 42},
+	})
+	li.DispatchEvent(event)
+
+	if selected, ok := detail.(itemSelected); !ok || selected.id != 42 {
+		t.Logf("The event detail must bubble with the event, got %v.", detail)
+		t.Fail()
+	}
+}
+
+func TestInitCustomEvent(t *testing.T) {
+	doc := parseTestDocument(t, `<div></div>`)
+
+	event, _ := doc.CreateEvent("CustomEvent")
+	custom := event.(CustomEvent)
+	custom.InitCustomEvent("panel-closed", true, true, "panel")
+
+	if custom.Type() != "panel-closed" || !custom.Bubbles() || !custom.Cancelable() || custom.Detail() != "panel" {
+		t.Log("InitCustomEvent must initialize the event type, options and detail.")
+		t.Fail()
+	}
+
+	// Initializing during the dispatch is a no-op
+	doc.AddEventListener("panel-closed", NewEventListener(func(event Event) {
+		event.(CustomEvent).InitCustomEvent("other", false, false, nil)
+	}), AddEventListenerOptions{})
+	doc.DispatchEvent(custom)
+
+	if custom.Type() != "panel-closed" || custom.Detail() != "panel" {
+		t.Log("InitCustomEvent must be a no-op during the dispatch.")
+		t.Fail()
+	}
+}
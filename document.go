@@ -1,6 +1,12 @@
 package gom
 
-import "golang.org/x/text/encoding"
+import (
+	"strings"
+	"time"
+
+	e "github.com/negrel/gom/exception"
+	"golang.org/x/text/encoding"
+)
 
 /* NOTE Document missing props & methods (OFFICIAL DOM) :
  * ** Props **
@@ -29,12 +35,8 @@ import "golang.org/x/text/encoding"
  * createAttributeNS
  * createCDATASection
  * createElementNS
- * createEvent
- * createNodeIterator
  * createProcessingInstruction
- * createRange
  * createTouchList
- * createTreeWalker
  * enableStyleSheetsForSet
  * hasStorageAccess
  * requestStorageAccess
@@ -49,7 +51,15 @@ import "golang.org/x/text/encoding"
 // https://developer.mozilla.org/en-US/docs/Web/API/Document
 // https://dom.spec.whatwg.org/#document
 type Document interface {
+	/* Private */
+	compileSelector(string) (Selector, e.Exception)
+	elementIds() *idIndex
+	liveRanges() map[*liveRange]struct{}
+	mutationObserverQueue() *mutationObserverQueue
+	nodeIterators() map[*nodeIterator]struct{}
+	/* EMBEDDED INTERFACE */
 	Node
+	ParentNode
 	/* GETTERS & SETTERS (props) */
 	Body() Node
 	CharacterSet() encoding.Encoding
@@ -62,44 +72,110 @@ type Document interface {
 	/* METHODS */
 	AdoptNode(Node)
 	CreateAttribute(string) Attr
-	CreateComment() Node
+	CreateComment(string) Comment
 	CreateDocumentFragment() DocumentFragment
 	CreateElement(string) Element
-	CreateTextNode() Node
-	GetElementsByClassName(string) Element
-	GetElementsByTagName(string) Element
+	CreateEvent(interfaceName string) (Event, e.Exception)
+	CreateNodeIterator(root Node, whatToShow uint32, filter NodeFilter) NodeIterator
+	CreateRange() Range
+	CreateTextNode(string) Text
+	CreateTreeWalker(root Node, whatToShow uint32, filter NodeFilter) TreeWalker
+	GetElementsByClassName(string) GOMLCollection
+	GetElementsByTagName(string) GOMLCollection
 	ImportNode(Node, bool) Node
 	GetElementById(string) Element
-	QuerySelector(string) Element
-	QuerySelectorAll(string) NodeList
+	NotifyMutationObservers()
 }
 
 var _ Document = &document{}
 
 type document struct {
 	*node
-	body            Node
+	*parentNodeMixin
 	characterSet    encoding.Encoding
-	docType         DocumentType
-	documentElement Element
-	head            Element
 	hidden          bool
+	ids             *idIndex
+	iterators       map[*nodeIterator]struct{}
+	observers       *mutationObserverQueue
+	ranges          map[*liveRange]struct{}
+	selectors       *selectorCache
 	visibilityState string
 }
 
 // NewDocument return a new document object serving
 // as an entry point into the page's content.
 func NewDocument(name string) Document {
-	return &document{
-		node:            &node{},
-		body:            newNode(),
+	d := newDocument()
+
+	docType := newDocumentType(name)
+	docType.SetOwnerDocument(d)
+	d.AppendChild(docType)
+
+	return d
+}
+
+// newDocument return a new document without any
+// child node.
+func newDocument() *document {
+	d := &document{
 		characterSet:    nil,
-		docType:         newDocumentType(name),
-		documentElement: nil,
-		head:            nil,
 		hidden:          false,
+		ids:             newIdIndex(),
+		iterators:       make(map[*nodeIterator]struct{}),
+		observers:       &mutationObserverQueue{},
+		ranges:          make(map[*liveRange]struct{}),
+		selectors:       newSelectorCache(selectorCacheSize),
 		visibilityState: "visible",
 	}
+	d.node = embedNode(d)
+	d.parentNodeMixin = newParentNode(d)
+
+	return d
+}
+
+// compileSelector return the compiled selector from the
+// document selector cache.
+func (d *document) compileSelector(source string) (Selector, e.Exception) {
+	return d.selectors.compile(source)
+}
+
+// elementIds return the id index of the document.
+func (d *document) elementIds() *idIndex {
+	return d.ids
+}
+
+// liveRanges return the set of Range to update when
+// the document is modified.
+func (d *document) liveRanges() map[*liveRange]struct{} {
+	return d.ranges
+}
+
+// mutationObserverQueue return the mutation observers
+// registered and pending in the document.
+func (d *document) mutationObserverQueue() *mutationObserverQueue {
+	return d.observers
+}
+
+// nodeIterators return the set of NodeIterator to update
+// when a node of the document is removed.
+func (d *document) nodeIterators() map[*nodeIterator]struct{} {
+	return d.iterators
+}
+
+// childElementByTagName return the first child element of
+// parent with the given tag name.
+func childElementByTagName(parent Node, tagName string) Element {
+	if parent == nil {
+		return nil
+	}
+
+	for child := parent.FirstChild(); child != nil; child = child.NextSibling() {
+		if el, isElement := child.(Element); isElement && el.TagName() == tagName {
+			return el
+		}
+	}
+
+	return nil
 }
 
 /*****************************************************
@@ -120,6 +196,16 @@ func (d *document) NodeType() NodeType {
 	return DocumentNode
 }
 
+// TextContent return an empty string for Document.
+// https://dom.spec.whatwg.org/#dom-node-textcontent
+func (d *document) TextContent() string {
+	return ""
+}
+
+// SetTextContent does nothing for Document.
+// https://dom.spec.whatwg.org/#dom-node-textcontent
+func (d *document) SetTextContent(string) {}
+
 /*****************************************************
  **************** Getters & Setters ******************
  *****************************************************/
@@ -128,7 +214,19 @@ func (d *document) NodeType() NodeType {
 // Body return the <body> element of the current document
 // https://developer.mozilla.org/en-US/docs/Web/API/Document/body
 func (d *document) Body() Node {
-	return d.body
+	if body := d.body(); body != nil {
+		return body
+	}
+
+	return nil
+}
+
+func (d *document) body() Element {
+	if docEl := d.DocumentElement(); docEl != nil {
+		return childElementByTagName(docEl, "body")
+	}
+
+	return nil
 }
 
 // CharacterSet return the current character set used by
@@ -142,20 +240,36 @@ func (d *document) CharacterSet() encoding.Encoding {
 // associated with current document.
 // https://developer.mozilla.org/en-US/docs/Web/API/Document/doctype
 func (d *document) DocType() DocumentType {
-	return d.docType
+	for child := d.FirstChild(); child != nil; child = child.NextSibling() {
+		if docType, isDocType := child.(DocumentType); isDocType {
+			return docType
+		}
+	}
+
+	return nil
 }
 
 // DocumentElement returns the Element that is the root
 // element of the document.
 // https://developer.mozilla.org/en-US/docs/Web/API/Document/documentElement
 func (d *document) DocumentElement() Element {
-	return d.documentElement
+	for child := d.FirstChild(); child != nil; child = child.NextSibling() {
+		if el, isElement := child.(Element); isElement {
+			return el
+		}
+	}
+
+	return nil
 }
 
 // Head return the <head> element of the current document
 // https://developer.mozilla.org/en-US/docs/Web/API/Document/head
 func (d *document) Head() Element {
-	return d.head
+	if docEl := d.DocumentElement(); docEl != nil {
+		return childElementByTagName(docEl, "head")
+	}
+
+	return nil
 }
 
 // Hidden returns a Boolean value indicating if the page
@@ -168,7 +282,18 @@ func (d *document) Hidden() bool {
 // SetBody set the body node of the document.
 // https://developer.mozilla.org/en-US/docs/Web/API/Document/body
 func (d *document) SetBody(body Node) {
-	d.body = body
+	docEl := d.DocumentElement()
+	if docEl == nil {
+		return
+	}
+
+	// Replacing the current body
+	if old := d.body(); old != nil {
+		docEl.ReplaceChild(body, old)
+		return
+	}
+
+	docEl.AppendChild(body)
 }
 
 // SetCharacterSet method set the document character
@@ -187,17 +312,21 @@ func (d *document) SetCharacterSet(charSet encoding.Encoding) {
 // into the document on which the method was called.
 // https://developer.mozilla.org/en-US/docs/Web/API/Document/adoptNode
 func (d *document) AdoptNode(external Node) {
+	// Documents can't be adopted
+	if external.NodeType() == DocumentNode {
+		return
+	}
+
 	// If external node have a parent
 	if extParent := external.ParentNode(); extParent != nil {
 		// Removing the child from the parent
 		external, _ = extParent.RemoveChild(external)
 	}
 
-	// Adopting the node
-	d.AppendChild(external)
-	// Changing ownerDocument of the child and subchild...
-	d.apply(func(node Node) {
+	// Changing ownerDocument of the node and subchild...
+	external.apply(func(node Node) {
 		node.SetOwnerDocument(d)
+		adoptMutationObservers(node, d)
 	})
 }
 
@@ -211,47 +340,116 @@ func (d *document) CreateAttribute(name string) Attr {
 // CreateComment creates a new comment node, and
 // returns it.
 // https://developer.mozilla.org/en-US/docs/Web/API/Document/createComment
-func (d *document) CreateComment() Node {
-	// TODO func (d *document) CreateComment() Node
+func (d *document) CreateComment(data string) Comment {
+	comment := createComment(data)
+	comment.SetOwnerDocument(d)
+
+	return comment
 }
 
-// CreateDocumentFragment creates a new comment node, and
+// CreateDocumentFragment creates a new empty DocumentFragment, and
 // returns it.
 // https://developer.mozilla.org/en-US/docs/Web/API/Document/createDocumentFragment
 func (d *document) CreateDocumentFragment() DocumentFragment {
-	return createDocumentFragment()
+	fragment := createDocumentFragment()
+	fragment.SetOwnerDocument(d)
+
+	return fragment
 }
 
-// CreateElement creates a new comment node, and
+// CreateElement creates a new GOML element, and
 // returns it.
 // https://developer.mozilla.org/en-US/docs/Web/API/Document/createElement
 func (d *document) CreateElement(tagName string) Element {
-	return createElement(tagName)
+	element := createGOMLElement(tagName)
+	element.SetOwnerDocument(d)
+
+	return element
+}
+
+// CreateEvent creates an uninitialized event of the given
+// interface ("Event", "CustomEvent" or "MouseEvent"), to be
+// initialized with InitEvent. A NotSupportedError is
+// returned for unknown interfaces.
+// https://developer.mozilla.org/en-US/docs/Web/API/Document/createEvent
+// https://dom.spec.whatwg.org/#dom-document-createevent
+func (d *document) CreateEvent(interfaceName string) (Event, e.Exception) {
+	ev := &event{
+		timeStamp: time.Now(),
+	}
+
+	switch strings.ToLower(interfaceName) {
+	case "customevent":
+		return &customEvent{event: ev}, nil
+
+	case "event", "events", "htmlevents", "svgevents":
+		return ev, nil
+
+	case "mouseevent", "mouseevents":
+		return &mouseEvent{event: ev}, nil
+	}
+
+	return nil, e.New(e.NotSupportedError, "The %v interface is not supported.", interfaceName)
+}
+
+// CreateNodeIterator creates a NodeIterator over the
+// subtree of root showing the nodes whose type is in the
+// whatToShow bitmask and accepted by the optional filter.
+// The root node document keeps the iterator to update it
+// when nodes are removed, call its Release method once the
+// iterator is no longer used.
+// https://developer.mozilla.org/en-US/docs/Web/API/Document/createNodeIterator
+func (d *document) CreateNodeIterator(root Node, whatToShow uint32, filter NodeFilter) NodeIterator {
+	// Iterators are updated by the root node document
+	doc := nodeDocument(root)
+	if doc == nil {
+		doc = d
+	}
+
+	return newNodeIterator(doc, root, whatToShow, filter)
+}
+
+// CreateRange creates a new Range collapsed at the
+// start of the document. The document keeps the range to
+// update it when the tree is modified, call its Release
+// method once the range is no longer used.
+// https://developer.mozilla.org/en-US/docs/Web/API/Document/createRange
+func (d *document) CreateRange() Range {
+	return newRange(d, 0)
 }
 
-// CreateTextNode creates a new comment node, and
+// CreateTextNode creates a new text node, and
 // returns it.
 // https://developer.mozilla.org/en-US/docs/Web/API/Document/createTextNode
 func (d *document) CreateTextNode(content string) Text {
-	return createTextNode(content)
+	text := createTextNode(content)
+	text.SetOwnerDocument(d)
+
+	return text
+}
+
+// CreateTreeWalker creates a TreeWalker over the subtree
+// of root showing the nodes whose type is in the whatToShow
+// bitmask and accepted by the optional filter.
+// https://developer.mozilla.org/en-US/docs/Web/API/Document/createTreeWalker
+func (d *document) CreateTreeWalker(root Node, whatToShow uint32, filter NodeFilter) TreeWalker {
+	return newTreeWalker(root, whatToShow, filter)
 }
 
 // GetElementsByClassName method of Document interface
 // returns an array-like object of all child elements
 // which have all of the given class names.
 // https://developer.mozilla.org/en-US/docs/Web/API/Document/getElementsByClassName
-func (d *document) GetElementsByClassName(className string) Element {
-	// TODO func (d *document) GetElementsByClassName(className string) Element
-	return createElement("")
+func (d *document) GetElementsByClassName(className string) GOMLCollection {
+	return elementsByClassName(d, className)
 }
 
 // GetElementsByTagName method of Document interface
 // returns an array-like object of all child elements
 // which have all of the given tag names.
 // https://developer.mozilla.org/en-US/docs/Web/API/Document/getElementsByTagName
-func (d *document) GetElementsByTagName(tagName string) Element {
-	// TODO func (d *document) GetElementsByTagName(tagName string) Element
-	return createElement("")
+func (d *document) GetElementsByTagName(tagName string) GOMLCollection {
+	return elementsByTagName(d, tagName)
 }
 
 // ImportNode method creates a copy of a Node or
@@ -267,21 +465,16 @@ func (d *document) ImportNode(node Node, deep bool) Node {
 // the element whose id property matches the specified string
 // https://developer.mozilla.org/en-US/docs/Web/API/Document/getElementById
 func (d *document) GetElementById(id string) Element {
-	// TODO func (d *document) GetElementById(id string) Element
-	return createElement("")
-}
-
-// QuerySelector returns the first Element within the document
-// that matches the specified selector, or group of selectors.
-// https://developer.mozilla.org/en-US/docs/Web/API/Document/querySelector
-func (d *document) QuerySelector(selector string) Element {
-	// TODO func (d *document) QuerySelector(selector string) Element
+	return d.ids.get(id)
 }
 
-// QuerySelectorAll returns a static (not live) NodeList
-// representing a list of the document's elements that match
-// the specified group of selectors.
-// https://developer.mozilla.org/en-US/docs/Web/API/Document/querySelectorAll
-func (d *document) QuerySelectorAll(selector string) NodeList {
-	// TODO func (d *document) QuerySelectorAll(selector string) NodeList
+// NotifyMutationObservers deliver the records queued in the
+// document to the callback of their observer, until no
+// record is queued. It is the checkpoint at which the
+// records are delivered: nothing is delivered until it is
+// called, and the transient registrations are kept until
+// then.
+// https://dom.spec.whatwg.org/#notify-mutation-observers
+func (d *document) NotifyMutationObservers() {
+	notifyMutationObservers(d)
 }
@@ -1,24 +1,89 @@
 package gom
 
-// TODO DocumentFragment
-
 // DocumentFragment object represents a
-// minimal document object that has no parent
+// minimal document object that has no parent.
+// When inserted, the fragment children are moved
+// in place of the fragment, leaving it empty.
 // https://developer.mozilla.org/en-US/docs/Web/API/DocumentFragment
 // https://dom.spec.whatwg.org/#documentfragment
 type DocumentFragment interface {
 	/* EMBEDDED INTERFACE */
 	Node
+	ParentNode
 }
 
+var _ DocumentFragment = &documentFragment{}
+var _ Node = &documentFragment{}
+
 type documentFragment struct {
-	node
+	*node
+	*parentNodeMixin
 }
 
-// NewDocumentFragment is the public constructor
-// for the DocumentFragment object.
+// createDocumentFragment return a new empty
+// DocumentFragment.
 // https://developer.mozilla.org/en-US/docs/Web/API/DocumentFragment/DocumentFragment
 func createDocumentFragment() DocumentFragment {
-	// TODO func createDocumentFragment() DocumentFragment
-	return &documentFragment{}
+	df := &documentFragment{}
+	df.node = embedNode(df)
+	df.parentNodeMixin = newParentNode(df)
+
+	return df
+}
+
+/*****************************************************
+ **************** Embedded interface *****************
+ *****************************************************/
+// ANCHOR Embedded interface
+
+/* Node */
+/* - Props */
+
+// NodeName return "#document-fragment".
+func (df *documentFragment) NodeName() string {
+	return "#document-fragment"
+}
+
+// NodeType return the "DocumentFragmentNode" type.
+func (df *documentFragment) NodeType() NodeType {
+	return DocumentFragmentNode
+}
+
+/* - Methods */
+
+// CloneNode return a clone of the DocumentFragment.
+// Set the deep argument to true if you want the
+// children to be cloned.
+func (df *documentFragment) CloneNode(deep bool) Node {
+	clone := createDocumentFragment()
+	clone.SetOwnerDocument(df.document)
+
+	if deep {
+		for child := df.FirstChild(); child != nil; child = child.NextSibling() {
+			clone.AppendChild(child.CloneNode(true))
+		}
+	}
+
+	return clone
+}
+
+// IsEqualNode return wether or not two DocumentFragment
+// are equal.
+func (df *documentFragment) IsEqualNode(other Node) bool {
+	if other == nil || other.NodeType() != DocumentFragmentNode {
+		return false
+	}
+
+	children, otherChildren := df.childNodes.Values(), other.ChildNodes().Values()
+	if len(children) != len(otherChildren) {
+		return false
+	}
+
+	for i, child := range children {
+		if !child.IsEqualNode(otherChildren[i]) {
+			return false
+		}
+	}
+
+	return true
 }
@@ -0,0 +1,107 @@
+package gom
+
+import (
+	"testing"
+)
+
+// newTestFragment return a fragment of doc containing
+// an element for each of the given tag names.
+func newTestFragment(doc Document, tagNames ...string) DocumentFragment {
+	fragment := doc.CreateDocumentFragment()
+
+	for _, tagName := range tagNames {
+		fragment.AppendChild(doc.CreateElement(tagName))
+	}
+
+	return fragment
+}
+
+func TestDocumentFragmentNode(t *testing.T) {
+	doc := NewDocument("goml")
+	fragment := newTestFragment(doc, "p", "span")
+
+	if fragment.NodeType() != DocumentFragmentNode || fragment.NodeName() != "#document-fragment" {
+		t.Log("DocumentFragment NodeType and NodeName are invalid.")
+		t.Fail()
+	}
+
+	if fragment.OwnerDocument() != doc {
+		t.Log("DocumentFragment owner document must be the creating document.")
+		t.Fail()
+	}
+
+	if first := fragment.FirstElementChild(); first == nil || first.TagName() != "p" {
+		t.Log("DocumentFragment first element child must be the paragraph.")
+		t.Fail()
+	}
+
+	if span, err := fragment.QuerySelector("span"); err != nil || span == nil {
+		t.Log("DocumentFragment QuerySelector must find its descendants.")
+		t.Fail()
+	}
+
+	clone := fragment.CloneNode(true)
+	if clone.ChildNodes().Length() != 2 || !clone.IsEqualNode(fragment) {
+		t.Log("DocumentFragment deep clone must be equal to the fragment.")
+		t.Fail()
+	}
+}
+
+func TestDocumentFragmentInsertion(t *testing.T) {
+	doc := parseTestDocument(t, `<div><b></b></div>`)
+	div := doc.DocumentElement()
+	b := div.FirstChild()
+
+	// AppendChild
+	fragment := newTestFragment(doc, "p", "span")
+	if inserted, err := div.AppendChild(fragment); err != nil || !inserted.IsSameNode(fragment) {
+		t.Fatalf("AppendChild must return the fragment : %v", err)
+	}
+
+	if div.InnerGOML() != "<b></b><p></p><span></span>" {
+		t.Logf("Fragment children must be appended in order, got %v.", div.InnerGOML())
+		t.Fail()
+	}
+
+	if fragment.HasChildNodes() {
+		t.Log("Fragment must be empty after insertion.")
+		t.Fail()
+	}
+
+	if p := div.ChildNodes().Item(1); !p.ParentNode().IsSameNode(div) || !p.ParentElement().IsSameNode(div) {
+		t.Log("Fragment children parent must be the new parent.")
+		t.Fail()
+	}
+
+	// InsertBefore
+	div.InsertBefore(newTestFragment(doc, "i", "u"), b)
+	if div.InnerGOML() != "<i></i><u></u><b></b><p></p><span></span>" {
+		t.Logf("Fragment children must be inserted before the reference, got %v.", div.InnerGOML())
+		t.Fail()
+	}
+
+	// ReplaceChild
+	if err := div.ReplaceChild(newTestFragment(doc, "em", "a"), b); err != nil {
+		t.Fatalf("ReplaceChild must not return an error : %v", err)
+	}
+
+	if div.InnerGOML() != "<i></i><u></u><em></em><a></a><p></p><span></span>" {
+		t.Logf("Fragment children must replace the child, got %v.", div.InnerGOML())
+		t.Fail()
+	}
+
+	if b.ParentNode() != nil {
+		t.Log("Replaced child must be removed.")
+		t.Fail()
+	}
+}
+
+func TestDocumentFragmentDocumentValidity(t *testing.T) {
+	doc := parseTestDocument(t, `<div></div>`)
+
+	_, err := doc.AppendChild(newTestFragment(doc, "p"))
+	if err == nil || err.Name() != "HierarchyRequestError" {
+		t.Log("Fragment element must not be inserted in a document having an element.")
+		t.Fail()
+	}
+}
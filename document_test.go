@@ -0,0 +1,115 @@
+package gom
+
+import (
+	"testing"
+)
+
+func TestGetElementById(t *testing.T) {
+	doc := parseTestDocument(t, `<div><p id="a">1</p><section><p id="b">2</p></section></div>`)
+	div := doc.DocumentElement()
+
+	a := doc.GetElementById("a")
+	if a == nil || a.FirstChild().(Text).Data() != "1" {
+		t.Fatal("Element with id \"a\" must be the first paragraph.")
+	}
+
+	if missing := doc.GetElementById("missing"); missing != nil {
+		t.Log("Missing id must return nil.")
+		t.Fail()
+	}
+
+	// Insertion
+	c := doc.CreateElement("span")
+	c.SetAttribute("id", "c")
+
+	if doc.GetElementById("c") != nil {
+		t.Log("Element not connected to the document must not be found.")
+		t.Fail()
+	}
+
+	div.AppendChild(c)
+	if found := doc.GetElementById("c"); found == nil || !found.IsSameNode(c) {
+		t.Log("Appended element must be found by its id.")
+		t.Fail()
+	}
+
+	// Removal of a subtree
+	section := doc.GetElementById("b").ParentNode()
+	div.RemoveChild(section)
+
+	if doc.GetElementById("b") != nil {
+		t.Log("Removed element must not be found by its id.")
+		t.Fail()
+	}
+
+	// Attribute changes
+	c.SetAttribute("id", "d")
+	if doc.GetElementById("c") != nil || doc.GetElementById("d") != c {
+		t.Log("Element must be found by its new id only.")
+		t.Fail()
+	}
+
+	c.GetAttribute("id").SetValue("e")
+	if doc.GetElementById("d") != nil || doc.GetElementById("e") != c {
+		t.Log("Element must be found by its new id attribute value only.")
+		t.Fail()
+	}
+
+	c.RemoveAttribute("id")
+	if doc.GetElementById("e") != nil {
+		t.Log("Element without id must not be found.")
+		t.Fail()
+	}
+
+	// Duplicate ids, tree order win
+	c.SetAttribute("id", "a")
+	if found := doc.GetElementById("a"); found != a {
+		t.Log("First element in tree order must win on duplicate ids.")
+		t.Fail()
+	}
+
+	div.RemoveChild(c)
+	div.InsertBefore(c, a)
+	if found := doc.GetElementById("a"); found != c {
+		t.Log("Inserted element must win on duplicate ids when it is first in tree order.")
+		t.Fail()
+	}
+}
+
+func TestCreateEvent(t *testing.T) {
+	doc := parseTestDocument(t, `<div></div>`)
+	div := doc.DocumentElement()
+
+	event, err := doc.CreateEvent("Event")
+	if err != nil {
+		t.Fatalf("Error while creating the event : %v", err)
+	}
+
+	if _, err := div.DispatchEvent(event); err == nil || err.Name() != "InvalidStateError" {
+		t.Logf("Dispatching an uninitialized event must return an InvalidStateError, got %v.", err)
+		t.Fail()
+	}
+
+	event.InitEvent("ping", true, false)
+	if ok, err := div.DispatchEvent(event); !ok || err != nil {
+		t.Logf("An initialized event must be dispatched, got %v (%v).", ok, err)
+		t.Fail()
+	}
+
+	interfaces := map[string]func(Event) bool{
+		"CustomEvent": func(ev Event) bool { _, ok := ev.(CustomEvent); return ok },
+		"mouseevents": func(ev Event) bool { _, ok := ev.(MouseEvent); return ok },
+		"HTMLEvents":  func(ev Event) bool { return ev != nil },
+	}
+	for name, isInterface := range interfaces {
+		if event, err := doc.CreateEvent(name); err != nil || !isInterface(event) {
+			t.Logf("CreateEvent(%q) must return an event of the interface, got %v (%v).", name, event, err)
+			t.Fail()
+		}
+	}
+
+	if _, err := doc.CreateEvent("TouchEvent"); err == nil || err.Name() != "NotSupportedError" {
+		t.Logf("An unknown interface must return a NotSupportedError, got %v.", err)
+		t.Fail()
+	}
+}
@@ -10,6 +10,7 @@ type DocumentType interface {
 	setSystemId(string)
 	/* EMBEDDED INTERFACE */
 	Node
+	ChildNode
 	/* GETTERS & SETTERS (props) */
 	Name() string
 	PublicId() string
@@ -20,18 +21,22 @@ var _ DocumentType = &documentType{}
 
 type documentType struct {
 	*node
+	*childNode
 	name     string
 	publicId string
 	systemId string
 }
 
 func newDocumentType(name string) DocumentType {
-	return &documentType{
-		node:     &node{},
+	dt := &documentType{
 		name:     name,
 		publicId: "",
 		systemId: "",
 	}
+	dt.node = embedNode(dt)
+	dt.childNode = newChildNode(dt)
+
+	return dt
 }
 
 func (dt *documentType) setName(name string) {
@@ -64,6 +69,16 @@ func (dt *documentType) NodeType() NodeType {
 	return DocumentTypeNode
 }
 
+// TextContent return an empty string for DocumentType.
+// https://dom.spec.whatwg.org/#dom-node-textcontent
+func (dt *documentType) TextContent() string {
+	return ""
+}
+
+// SetTextContent does nothing for DocumentType.
+// https://dom.spec.whatwg.org/#dom-node-textcontent
+func (dt *documentType) SetTextContent(string) {}
+
 /* - Methods */
 
 // CloneNode return a clone of the DocumentType.
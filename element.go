@@ -1,7 +1,10 @@
 package gom
 
 import (
+	"fmt"
 	"strings"
+
+	"github.com/negrel/gom/exception"
 )
 
 /* NOTE Element missing props & methods (OFFICIAL DOM) :
@@ -17,9 +20,6 @@ import (
  * all obsolete or non-standardized props
  *
  * ** Methods **
- * insertAdjacentElement
- * insertAdjacentHTML
- * insertAdjacentText
  * releasePointerCapture
  * removeAttributeNS
  * setPointerCapture
@@ -32,9 +32,13 @@ import (
 // https://developer.mozilla.org/en-US/docs/Web/API/Element
 // https://dom.spec.whatwg.org/#interface-element
 type Element interface {
+	/* Private */
+	attributeChanged(name string, oldValue, newValue *string)
 	/* EMBEDDED INTERFACE */
 	Node
+	ChildNode
 	NonDocumentTypeChildNode
+	ParentNode
 	/* GETTERS & SETTERS (props) */
 	Attributes() NamedNodeMap
 	ClassList() []string
@@ -49,12 +53,13 @@ type Element interface {
 	ScrollTop() int
 	ScrollWidth() int
 	SetClassName(string)
-	SetInnerGOML(string)
-	SetOuterGOML(string)
+	SetInnerGOML(string) exception.Exception
+	SetOuterGOML(string) exception.Exception
 	SetScrollTop(int)
 	SetScrollLeft(int)
 	TagName() string
 	/* METHODS */
+	Closest(string) (Element, exception.Exception)
 	GetAttribute(string) Attr
 	GetAttributeNames() []string
 	GetBoundingClientRect() GOMRect
@@ -62,8 +67,10 @@ type Element interface {
 	GetElementsByClassName(string) GOMLCollection
 	GetElementsByTagName(string) GOMLCollection
 	HasAttribute(string) bool
-	QuerySelector(string) Node
-	QuerySelectorAll(string) NodeList
+	InsertAdjacentElement(position string, element Element) (Element, exception.Exception)
+	InsertAdjacentGOML(position, markup string) exception.Exception
+	InsertAdjacentText(position, data string) exception.Exception
+	Matches(string) (bool, exception.Exception)
 	RemoveAttribute(string)
 	Scroll(x, y int)
 	ScrollBy(x, y int)
@@ -77,19 +84,123 @@ var _ Node = &element{}
 
 type element struct {
 	*node
+	*childNode
 	*nonDocumentTypeChildNode
+	*parentNodeMixin
 	attributes NamedNodeMap
-	classList  []string
 	tagName    string
-	id         Attr
 }
 
 func createElement(tagName string) Element {
-	return &element{
-		tagName: tagName,
+	e := &element{}
+	e.init(e, tagName)
+
+	return e
+}
+
+// init initialize the element embedded by the given
+// self element.
+func (e *element) init(self Element, tagName string) {
+	e.node = embedNode(self)
+	e.childNode = newChildNode(self)
+	e.nonDocumentTypeChildNode = newNonDocumentTypeChildNode(self)
+	e.parentNodeMixin = newParentNode(self)
+	e.attributes = newNamedNodeMap(self)
+	e.tagName = strings.ToLower(tagName)
+}
+
+// attributeChanged is called when an attribute of the
+// element is added, changed or removed. Values are nil
+// when the attribute is absent.
+func (e *element) attributeChanged(name string, oldValue, newValue *string) {
+	e.touch()
+
+	queueMutationRecord(&mutationRecord{
+		recordType:    MutationAttributes,
+		target:        e.node.self,
+		attributeName: name,
+		oldValue:      oldValue,
+	})
+
+	if name != "id" {
+		return
+	}
+
+	if doc := connectedDocument(e.node.self); doc != nil {
+		self := e.node.self.(Element)
+
+		if oldValue != nil {
+			doc.elementIds().remove(*oldValue, self)
+		}
+		if newValue != nil {
+			doc.elementIds().add(*newValue, self)
+		}
 	}
 }
 
+// The positions relative to the element used by the
+// InsertAdjacent methods.
+const (
+	beforeBegin = "beforebegin"
+	afterBegin  = "afterbegin"
+	beforeEnd   = "beforeend"
+	afterEnd    = "afterend"
+)
+
+// insertAdjacent insert the node at the given position
+// relative to the element. A NoModificationAllowedError
+// exception is returned for the outer positions if the
+// element has no parent and a SyntaxError exception for
+// unknown positions.
+// https://dom.spec.whatwg.org/#insert-adjacent
+func (e *element) insertAdjacent(position string, node Node) (Node, exception.Exception) {
+	self := e.node.self
+	var err exception.Exception
+
+	switch strings.ToLower(position) {
+	case beforeBegin:
+		parent := e.ParentNode()
+		if parent == nil {
+			return nil, noParentError(position)
+		}
+		node, err = parent.InsertBefore(node, self)
+
+	case afterBegin:
+		node, err = e.InsertBefore(node, e.FirstChild())
+
+	case beforeEnd:
+		node, err = e.AppendChild(node)
+
+	case afterEnd:
+		parent := e.ParentNode()
+		if parent == nil {
+			return nil, noParentError(position)
+		}
+		node, err = parent.InsertBefore(node, e.NextSibling())
+
+	default:
+		return nil, invalidPositionError(position)
+	}
+
+	return node, err
+}
+
+// invalidPositionError return the SyntaxError exception
+// for unknown InsertAdjacent positions.
+func invalidPositionError(position string) exception.Exception {
+	return exception.New(exception.SyntaxError,
+		"%q is not a valid position, it must be one of '%v', '%v', '%v' or '%v'.",
+		position, beforeBegin, afterBegin, beforeEnd, afterEnd)
+}
+
+// noParentError return the NoModificationAllowedError
+// exception returned for the outer InsertAdjacent
+// positions of an element without parent.
+func noParentError(position string) exception.Exception {
+	return exception.New(exception.NoModificationAllowedError,
+		"The element has no parent element, nodes can't be inserted at position %q.", position)
+}
+
 /*****************************************************
  **************** Embedded interface *****************
  *****************************************************/
@@ -112,7 +223,7 @@ func (e *element) NodeType() NodeType {
 
 // CloneNode return a clone of the element
 func (e *element) CloneNode(deep bool) Node {
-	clone := createElement(e.tagName)
+	clone := createGOMLElement(e.TagName())
 
 	// Setting owner document
 	clone.SetOwnerDocument(e.document)
@@ -127,7 +238,7 @@ func (e *element) CloneNode(deep bool) Node {
 
 	// If deep clone, cloning the children
 	if deep {
-		for _, child := range e.ChildNodes().Values() {
+		for child := e.FirstChild(); child != nil; child = child.NextSibling() {
 			clone.AppendChild(child.CloneNode(true))
 		}
 	}
@@ -193,13 +304,17 @@ func (e *element) Attributes() NamedNodeMap {
 // class attributes element.
 // https://developer.mozilla.org/en-US/docs/Web/API/Element/attributehttps://developer.mozilla.org/en-US/docs/Web/API/Element/classList
 func (e *element) ClassList() (list []string) {
-	return e.classList
+	return strings.Fields(e.ClassName())
 }
 
 // ClassName return the class attribute as a string.
 // https://developer.mozilla.org/en-US/docs/Web/API/Element/className
 func (e *element) ClassName() string {
-	return strings.Join(e.classList, " ")
+	if class := e.GetAttribute("class"); class != nil {
+		return class.Value()
+	}
+
+	return ""
 }
 
 // ClientHeight return the inner height of an element
@@ -219,23 +334,22 @@ func (e *element) ClientWidth() int {
 // Id return the id property of the element.
 // https://developer.mozilla.org/en-US/docs/Web/API/Element/id
 func (e *element) Id() Attr {
-	return e.id
+	return e.GetAttribute("id")
 }
 
 // InnerGOML return the GOML markup contained within the
-// element.
+// element or an empty string if it can't be serialized.
 // https://developer.mozilla.org/en-US/docs/Web/API/Element/innerHTML
 func (e *element) InnerGOML() string {
-	// TODO func (e *element) InnerGOML() *string
-	return ""
+	return serializeChildren(e.node.self)
 }
 
 // OuterGOML return the serialized GOML fragment describing
-// the element including its descendants.
+// the element including its descendants or an empty string
+// if it can't be serialized.
 // https://developer.mozilla.org/en-US/docs/Web/API/Element/outerHTML
 func (e *element) OuterGOML() string {
-	// TODO func (e *element) OuterGOML() *string
-	return ""
+	return serializeNode(e.node.self)
 }
 
 // ScrollHeight is a measurement of the height of an element's
@@ -275,21 +389,66 @@ func (e *element) ScrollWidth() (sw int) {
 // SetClassName set the class attribute of the element.
 // https://developer.mozilla.org/en-US/docs/Web/API/Element/className
 func (e *element) SetClassName(className string) {
-	e.classList = strings.Split(className, " ")
+	e.SetAttribute("class", className)
 }
 
 // SetInnerGOML set the GOML markup contained within
 // the element.
 // https://developer.mozilla.org/en-US/docs/Web/API/Element/innerHTML
-func (e *element) SetInnerGOML(string) {
-	// TODO func (e *element) SetInnerGOML(string)
+func (e *element) SetInnerGOML(markup string) exception.Exception {
+	self := e.node.self.(Element)
+
+	nodes, err := parseFragment(markup, self)
+	if err != nil {
+		return err
+	}
+
+	fragment := createDocumentFragment()
+	fragment.SetOwnerDocument(e.OwnerDocument())
+	for _, node := range nodes {
+		fragment.AppendChild(node)
+	}
+
+	e.replaceAll(fragment)
+
+	return nil
 }
 
 // SetOuterGOML set the serialized GOML fragment
 // describing the element including its descendants.
 // https://developer.mozilla.org/en-US/docs/Web/API/Element/outerHTML
-func (e *element) SetOuterGOML(string) {
-	// TODO func (e *element) SetOuterGOML(string)
+func (e *element) SetOuterGOML(markup string) exception.Exception {
+	parent := e.ParentNode()
+
+	// Element without parent can't be replaced
+	if parent == nil {
+		return nil
+	}
+
+	if parent.NodeType() == DocumentNode {
+		return exception.New(exception.NoModificationAllowedError, "The element's parent is a Document.")
+	}
+
+	// The fragment is parsed in the context of the parent
+	context, isElement := parent.(Element)
+	if !isElement {
+		context = createElement("body")
+		context.SetOwnerDocument(e.OwnerDocument())
+	}
+
+	nodes, err := parseFragment(markup, context)
+	if err != nil {
+		return err
+	}
+
+	// Replacing the element by the nodes
+	self := e.node.self
+	for _, node := range nodes {
+		parent.InsertBefore(node, self)
+	}
+	parent.RemoveChild(self)
+
+	return nil
 }
 
 // SetScrollTop set the number of pixels the top of
@@ -306,8 +465,7 @@ func (e *element) SetScrollLeft(int) {}
 // it's called.
 // https://developer.mozilla.org/en-US/docs/Web/API/Element/tagName
 func (e *element) TagName() string {
-	// elements is not instanciable
-	return ""
+	return e.tagName
 }
 
 /*****************************************************
@@ -315,11 +473,33 @@ func (e *element) TagName() string {
  *****************************************************/
 // ANCHOR Methods
 
+// Closest method traverses the Element and its parents
+// (heading toward the document root) until it finds a node
+// that matches the provided selector string.
+// https://developer.mozilla.org/en-US/docs/Web/API/Element/closest
+func (e *element) Closest(selector string) (Element, exception.Exception) {
+	self := e.node.self.(Element)
+
+	compiled, err := compileSelector(self, selector)
+	if err != nil {
+		return nil, err
+	}
+
+	list := compiled.(*compiledSelector).list
+	for el := self; el != nil; el = el.ParentElement() {
+		if list.match(el, self) {
+			return el, nil
+		}
+	}
+
+	return nil, nil
+}
+
 // GetAttribute return the value of a specified attribute
 // on the element.
 // https://developer.mozilla.org/en-US/docs/Web/API/Element/getAttribute
 func (e *element) GetAttribute(attrName string) Attr {
-	attr, _ := e.attributes.getNamedItem(attrName)
+	attr, _ := e.attributes.getNamedItem(strings.ToLower(attrName))
 	return attr
 }
 
@@ -327,10 +507,10 @@ func (e *element) GetAttribute(attrName string) Attr {
 // from the current element.
 // https://developer.mozilla.org/en-US/docs/Web/API/Element/getAttributeName
 func (e *element) GetAttributeNames() []string {
-	attrNames := make([]string, e.attributes.Length())
+	attrNames := make([]string, 0, e.attributes.Length())
 
-	for i := 0; i < e.attributes.Length(); i++ {
-		attrNames = append(attrNames, e.attributes.Item(i).Name())
+	for _, attr := range e.attributes.Values() {
+		attrNames = append(attrNames, attr.Name())
 	}
 
 	return attrNames
@@ -357,16 +537,14 @@ func (e *element) GetClientRects() []GOMRect {
 // the specified class name or names.
 // https://developer.mozilla.org/en-US/docs/Web/API/Element/getElementsByClassName
 func (e *element) GetElementsByClassName(className string) GOMLCollection {
-	// TODO func (e *element) GetElementsByClassName() GOMLCollection
-	return newGOMLCollection()
+	return elementsByClassName(e.node.self, className)
 }
 
 // GetElementsByTagName method returns a live GOMLCollection
 // of elements with the given tag name.
 // https://developer.mozilla.org/en-US/docs/Web/API/Element/getElementsByTagName
 func (e *element) GetElementsByTagName(tagName string) GOMLCollection {
-	// TODO func (e *element) GetElementsByTagName() GOMLCollection
-	return newGOMLCollection()
+	return elementsByTagName(e.node.self, tagName)
 }
 
 // HasAttribute method returns a Boolean value indicating
@@ -374,34 +552,109 @@ func (e *element) GetElementsByTagName(tagName string) GOMLCollection {
 // or not.
 // https://developer.mozilla.org/en-US/docs/Web/API/Element/hasAttribute
 func (e *element) HasAttribute(name string) bool {
-	_, has := e.attributes.getNamedItem(name)
+	_, has := e.attributes.getNamedItem(strings.ToLower(name))
 	return has
 }
 
-// QuerySelector method returns the first element that is
-// a descendant of the element on which it is invoked that
-// matches the specified group of selectors.
-// https://developer.mozilla.org/en-US/docs/Web/API/Element/querySelector
-func (e *element) QuerySelector(selector string) Node {
-	// TODO func (e *element) QuerySelector(selector string) Node
-	return newNode()
+// InsertAdjacentElement inserts the given element at the
+// given position relative to the element and returns it.
+// A NoModificationAllowedError exception is returned for
+// the outer positions if the element has no parent.
+// https://developer.mozilla.org/en-US/docs/Web/API/Element/insertAdjacentElement
+func (e *element) InsertAdjacentElement(position string, element Element) (Element, exception.Exception) {
+	if _, err := e.insertAdjacent(position, element); err != nil {
+		return nil, err
+	}
+
+	return element, nil
+}
+
+// InsertAdjacentGOML parses the GOML markup and inserts the
+// resulting nodes at the given position relative to the
+// element. A NoModificationAllowedError exception is returned
+// for the outer positions if the element has no parent or if
+// the parent is a Document.
+// https://developer.mozilla.org/en-US/docs/Web/API/Element/insertAdjacentHTML
+func (e *element) InsertAdjacentGOML(position, markup string) exception.Exception {
+	var context Element
+
+	switch strings.ToLower(position) {
+	case beforeBegin, afterEnd:
+		parent := e.ParentNode()
+		if parent == nil {
+			return noParentError(position)
+		}
+		if parent.NodeType() == DocumentNode {
+			return exception.New(exception.NoModificationAllowedError,
+				"The element's parent is a Document, nodes can't be inserted at position %q.", position)
+		}
+
+		// The parent may be a DocumentFragment
+		var isElement bool
+		if context, isElement = parent.(Element); !isElement {
+			context = createElement("body")
+			context.SetOwnerDocument(e.OwnerDocument())
+		}
+
+	case afterBegin, beforeEnd:
+		context = e.node.self.(Element)
+
+	default:
+		return invalidPositionError(position)
+	}
+
+	nodes, err := parseFragment(markup, context)
+	if err != nil {
+		return err
+	}
+
+	fragment := createDocumentFragment()
+	fragment.SetOwnerDocument(e.OwnerDocument())
+	for _, node := range nodes {
+		fragment.AppendChild(node)
+	}
+
+	_, exc := e.insertAdjacent(position, fragment)
+	return exc
+}
+
+// InsertAdjacentText inserts a Text node containing the
+// given data at the given position relative to the element.
+// A NoModificationAllowedError exception is returned for
+// the outer positions if the element has no parent.
+// https://developer.mozilla.org/en-US/docs/Web/API/Element/insertAdjacentText
+func (e *element) InsertAdjacentText(position, data string) exception.Exception {
+	var text Text
+	if doc := e.OwnerDocument(); doc != nil {
+		text = doc.CreateTextNode(data)
+	} else {
+		text = createTextNode(data)
+	}
+
+	_, err := e.insertAdjacent(position, text)
+	return err
 }
 
-// QuerySelectorAll returns a static (not live) NodeList
-// representing a list of elements matching the specified
-// group of selectors which are descendants of the element
-// on which the method was called.
-// https://developer.mozilla.org/en-US/docs/Web/API/Element/querySelectorAll
-func (e *element) QuerySelectorAll(selector string) NodeList {
-	// TODO func (e *element) QuerySelectorAll(selector string) Node
-	return newNodeList()
+// Matches method checks to see if the Element would be
+// selected by the provided selector string.
+// https://developer.mozilla.org/en-US/docs/Web/API/Element/matches
+func (e *element) Matches(selector string) (bool, exception.Exception) {
+	self := e.node.self.(Element)
+
+	compiled, err := compileSelector(self, selector)
+	if err != nil {
+		return false, err
+	}
+
+	return compiled.Match(self), nil
 }
 
 // RemoveAttribute removes the attribute with the specified
 // name from the element.
 // https://developer.mozilla.org/en-US/docs/Web/API/Element/removeAttribute
 func (e *element) RemoveAttribute(attrName string) {
-	// TODO func (e *element) RemoveAttribute(attrName string)
+	// Removing a missing attribute is a no-op.
+	_, _ = e.attributes.RemoveNamedItem(strings.ToLower(attrName))
 }
 
 // Scroll method of the Element interface scrolls the element
@@ -426,8 +679,21 @@ func (e *element) ScrollTo(x, y int) {
 }
 
 // SetAttribute set the given attribute with the given value
+// https://developer.mozilla.org/en-US/docs/Web/API/Element/setAttribute
 func (e *element) SetAttribute(name string, value interface{}) {
-	// TODO func (e *element) SetAttribute(name string, value interface{})
+	name = strings.ToLower(name)
+
+	// Updating the existing attribute
+	if attr := e.GetAttribute(name); attr != nil {
+		attr.SetValue(fmt.Sprint(value))
+		return
+	}
+
+	attr := createAttribute(name)
+	attr.SetOwnerDocument(e.OwnerDocument())
+	attr.SetValue(fmt.Sprint(value))
+
+	e.attributes.SetNamedItem(attr)
 }
 
 // ToggleAttribute method of the Element interface toggles a
@@ -0,0 +1,280 @@
+package gom
+
+import (
+	"strings"
+	"testing"
+)
+
+// parseTestDocument parse the given GOML source and fail
+// the test on error.
+func parseTestDocument(t *testing.T, src string) Document {
+	doc, err := ParseDocument(strings.NewReader(src))
+	if err != nil {
+		t.Fatalf("Error while parsing the document : %v", err)
+	}
+
+	return doc
+}
+
+func TestElementCloneNode(t *testing.T) {
+	doc := NewDocument("goml")
+	div := doc.CreateElement("div")
+	div.AppendChild(doc.CreateElement("span"))
+
+	clone := div.CloneNode(true)
+	if _, isGOML := clone.(GOMLElement); !isGOML {
+		t.Logf("The clone of a GOML element must be a GOMLElement, got %T.", clone)
+		t.Fail()
+	}
+
+	if span, isSpan := clone.FirstChild().(*GOMLSpanElement); !isSpan {
+		t.Logf("The clone of a span must be a *GOMLSpanElement, got %T.", clone.FirstChild())
+		t.Fail()
+	} else if span.OwnerDocument() != doc {
+		t.Log("The clone must be owned by the document of the element.")
+		t.Fail()
+	}
+}
+
+func TestSetInnerGOML(t *testing.T) {
+	doc := parseTestDocument(t, `<div><p>old</p></div>`)
+	div := doc.DocumentElement()
+
+	err := div.SetInnerGOML(`<span class="a">Hello</span> World`)
+	if err != nil {
+		t.Fatalf("Error while setting inner GOML : %v", err)
+	}
+
+	if inner := div.InnerGOML(); inner != `<span class="a">Hello</span> World` {
+		t.Logf("Div inner GOML must be the new markup, got %q.", inner)
+		t.Fail()
+	}
+
+	span := div.FirstChild()
+	if same := span.ParentNode().IsSameNode(div); !same {
+		t.Log("Span parent node must be the div.")
+		t.Fail()
+	}
+
+	if same := span.ParentElement().IsSameNode(div); !same {
+		t.Log("Span parent element must be the div.")
+		t.Fail()
+	}
+
+	if same := span.OwnerDocument().IsSameNode(doc); !same {
+		t.Log("Span owner document must be the div owner document.")
+		t.Fail()
+	}
+
+	/*
+	 * Testing error
+	 */
+
+	err = div.SetInnerGOML(`<span>`)
+	if err == nil || err.Name() != "SyntaxError" {
+		t.Logf("Setting an invalid inner GOML must return a SyntaxError, got %v.", err)
+		t.Fail()
+	}
+
+	// Checking that the children are untouched
+	if inner := div.InnerGOML(); inner != `<span class="a">Hello</span> World` {
+		t.Logf("Div inner GOML must not change on error, got %q.", inner)
+		t.Fail()
+	}
+}
+
+func TestSetOuterGOML(t *testing.T) {
+	doc := parseTestDocument(t, `<div><p>old</p><hr></div>`)
+	div := doc.DocumentElement()
+	p := div.FirstChild().(Element)
+
+	err := p.SetOuterGOML(`<span>a</span><span>b</span>`)
+	if err != nil {
+		t.Fatalf("Error while setting outer GOML : %v", err)
+	}
+
+	if inner := div.InnerGOML(); inner != `<span>a</span><span>b</span><hr>` {
+		t.Logf("Div inner GOML must contain the new markup, got %q.", inner)
+		t.Fail()
+	}
+
+	if p.ParentNode() != nil {
+		t.Log("Replaced element must not have a parent anymore.")
+		t.Fail()
+	}
+
+	/*
+	 * Testing error
+	 */
+
+	err = div.SetOuterGOML(`<div></div>`)
+	if err == nil || err.Name() != "NoModificationAllowedError" {
+		t.Logf("Setting outer GOML of the document element must return a NoModificationAllowedError, got %v.", err)
+		t.Fail()
+	}
+
+	hr := div.LastChild().(Element)
+	err = hr.SetOuterGOML(`</hr>`)
+	if err == nil || err.Name() != "SyntaxError" {
+		t.Logf("Setting an invalid outer GOML must return a SyntaxError, got %v.", err)
+		t.Fail()
+	}
+}
+
+func TestMatches(t *testing.T) {
+	doc := parseTestDocument(t, `<div class="panel"><button id="ok" class="primary">OK</button></div>`)
+	button, _ := doc.QuerySelector("#ok")
+
+	tests := []struct {
+		selector string
+		expected bool
+	}{
+		{"button", true},
+		{"div > button.primary", true},
+		{".panel button:only-child", true},
+		{":scope", true},
+		{"a, #ok", true},
+		{"div", false},
+		{"button:not(.primary)", false},
+	}
+
+	for _, test := range tests {
+		matches, err := button.Matches(test.selector)
+		if err != nil {
+			t.Logf("Matches(%q) must not return an error : %v", test.selector, err)
+			t.Fail()
+			continue
+		}
+
+		if matches != test.expected {
+			t.Logf("Matches(%q) must return %v.", test.selector, test.expected)
+			t.Fail()
+		}
+	}
+
+	/*
+	 * Testing error
+	 */
+
+	if _, err := button.Matches("button["); err == nil || err.Name() != "SyntaxError" {
+		t.Logf("Matches with an invalid selector must return a SyntaxError, got %v.", err)
+		t.Fail()
+	}
+}
+
+func TestClosest(t *testing.T) {
+	doc := parseTestDocument(t, `<div class="panel"><section class="panel"><p><span>a</span></p></section></div>`)
+	span, _ := doc.QuerySelector("span")
+	section, _ := doc.QuerySelector("section")
+
+	// Closest start with the element itself
+	if closest, _ := span.Closest("span"); closest == nil || !closest.IsSameNode(span) {
+		t.Log("Closest span of the span must be the span itself.")
+		t.Fail()
+	}
+
+	if closest, _ := span.Closest(".panel"); closest == nil || !closest.IsSameNode(section) {
+		t.Log("Closest .panel of the span must be the section.")
+		t.Fail()
+	}
+
+	if closest, _ := span.Closest("div > .panel p"); closest == nil || closest.TagName() != "p" {
+		t.Log("Closest \"div > .panel p\" of the span must be the p element.")
+		t.Fail()
+	}
+
+	if closest, err := span.Closest("ul"); closest != nil || err != nil {
+		t.Log("Closest ul of the span must be nil.")
+		t.Fail()
+	}
+
+	/*
+	 * Testing error
+	 */
+
+	if _, err := span.Closest(">"); err == nil || err.Name() != "SyntaxError" {
+		t.Logf("Closest with an invalid selector must return a SyntaxError, got %v.", err)
+		t.Fail()
+	}
+}
+
+func TestInsertAdjacent(t *testing.T) {
+	doc := parseTestDocument(t, `<div><p>text</p></div>`)
+	div := doc.DocumentElement()
+	p := div.FirstChild().(Element)
+
+	for _, position := range []string{"beforebegin", "afterbegin", "beforeend", "afterend"} {
+		if _, err := p.InsertAdjacentElement(position, doc.CreateElement("i")); err != nil {
+			t.Fatalf("InsertAdjacentElement must not return an error : %v", err)
+		}
+	}
+
+	if div.InnerGOML() != "<i></i><p><i></i>text<i></i></p><i></i>" {
+		t.Logf("Elements must be inserted at the given positions, got %v.", div.InnerGOML())
+		t.Fail()
+	}
+
+	if err := p.InsertAdjacentText("AfterBegin", "a"); err != nil {
+		t.Fatalf("InsertAdjacentText must not return an error : %v", err)
+	}
+
+	if err := p.InsertAdjacentGOML("afterend", `<b>1</b>2`); err != nil {
+		t.Fatalf("InsertAdjacentGOML must not return an error : %v", err)
+	}
+
+	if div.InnerGOML() != "<i></i><p>a<i></i>text<i></i></p><b>1</b>2<i></i>" {
+		t.Logf("Text and GOML must be inserted at the given positions, got %v.", div.InnerGOML())
+		t.Fail()
+	}
+}
+
+func TestInsertAdjacentErrors(t *testing.T) {
+	doc := parseTestDocument(t, `<div></div>`)
+	div := doc.DocumentElement()
+
+	if err := div.InsertAdjacentText("middle", "a"); err == nil || err.Name() != "SyntaxError" {
+		t.Log("Unknown position must return a SyntaxError exception.")
+		t.Fail()
+	}
+
+	if err := div.InsertAdjacentGOML("middle", "a"); err == nil || err.Name() != "SyntaxError" {
+		t.Log("Unknown position must return a SyntaxError exception.")
+		t.Fail()
+	}
+
+	if err := div.InsertAdjacentGOML("beforebegin", "<p></p>"); err == nil || err.Name() != "NoModificationAllowedError" {
+		t.Log("GOML can't be inserted next to the document element.")
+		t.Fail()
+	}
+
+	detached := doc.CreateElement("p")
+	if err := detached.InsertAdjacentGOML("afterend", "<p></p>"); err == nil || err.Name() != "NoModificationAllowedError" {
+		t.Log("GOML can't be inserted next to an element without parent.")
+		t.Fail()
+	}
+
+	if el, err := detached.InsertAdjacentElement("afterend", doc.CreateElement("i")); el != nil || err == nil || err.Name() != "NoModificationAllowedError" {
+		t.Log("Element can't be inserted next to an element without parent.")
+		t.Fail()
+	}
+
+	if el, err := detached.InsertAdjacentElement("beforebegin", doc.CreateElement("i")); el != nil || err == nil || err.Name() != "NoModificationAllowedError" {
+		t.Log("Element can't be inserted next to an element without parent.")
+		t.Fail()
+	}
+
+	if err := detached.InsertAdjacentText("beforebegin", "a"); err == nil || err.Name() != "NoModificationAllowedError" {
+		t.Log("Text can't be inserted next to an element without parent.")
+		t.Fail()
+	}
+
+	if err := detached.InsertAdjacentText("afterend", "a"); err == nil || err.Name() != "NoModificationAllowedError" {
+		t.Log("Text can't be inserted next to an element without parent.")
+		t.Fail()
+	}
+
+	if err := div.InsertAdjacentGOML("beforeend", "<p>"); err == nil {
+		t.Log("Invalid GOML must return an exception.")
+		t.Fail()
+	}
+}
@@ -0,0 +1,222 @@
+package gom
+
+import "time"
+
+// Event interface represents an event which takes place
+// in the tree and is dispatched to an EventTarget.
+// https://developer.mozilla.org/en-US/docs/Web/API/Event
+// https://dom.spec.whatwg.org/#interface-event
+type Event interface {
+	/* Private */
+	state() *event
+	/* GETTERS & SETTERS (props) */
+	Bubbles() bool
+	Cancelable() bool
+	CurrentTarget() EventTarget
+	DefaultPrevented() bool
+	EventPhase() int
+	IsTrusted() bool
+	Target() EventTarget
+	TimeStamp() time.Time
+	Type() string
+	/* METHODS */
+	ComposedPath() []EventTarget
+	InitEvent(eventType string, bubbles, cancelable bool)
+	PreventDefault()
+	StopImmediatePropagation()
+	StopPropagation()
+}
+
+// EventInit contains the options of NewEvent.
+// https://dom.spec.whatwg.org/#dictdef-eventinit
+type EventInit struct {
+	Bubbles    bool
+	Cancelable bool
+}
+
+// The EventPhase values.
+const (
+	EventPhaseNone = iota
+	EventPhaseCapturing
+	EventPhaseAtTarget
+	EventPhaseBubbling
+)
+
+var _ Event = &event{}
+
+type event struct {
+	eventType     string
+	bubbles       bool
+	cancelable    bool
+	target        EventTarget
+	currentTarget EventTarget
+	eventPhase    int
+	timeStamp     time.Time
+	isTrusted     bool
+	// path is the propagation path of the event being
+	// dispatched, from the target to the root.
+	path []EventTarget
+	// The event flags.
+	// https://dom.spec.whatwg.org/#stop-propagation-flag
+	initialized              bool
+	dispatching              bool
+	canceled                 bool
+	inPassiveListener        bool
+	stopPropagation          bool
+	stopImmediatePropagation bool
+}
+
+// NewEvent return a new Event of the given type.
+// https://developer.mozilla.org/en-US/docs/Web/API/Event/Event
+func NewEvent(eventType string, init EventInit) Event {
+	return newEvent(eventType, init)
+}
+
+func newEvent(eventType string, init EventInit) *event {
+	ev := &event{
+		timeStamp: time.Now(),
+	}
+	ev.initEvent(eventType, init.Bubbles, init.Cancelable)
+
+	return ev
+}
+
+// initEvent initialize the event type and flags.
+// https://dom.spec.whatwg.org/#concept-event-initialize
+func (ev *event) initEvent(eventType string, bubbles, cancelable bool) {
+	ev.initialized = true
+	ev.stopPropagation = false
+	ev.stopImmediatePropagation = false
+	ev.canceled = false
+	ev.isTrusted = false
+	ev.target = nil
+	ev.eventType = eventType
+	ev.bubbles = bubbles
+	ev.cancelable = cancelable
+}
+
+func (ev *event) state() *event {
+	return ev
+}
+
+/*****************************************************
+ **************** Getters & Setters ******************
+ *****************************************************/
+// ANCHOR Getters & Setters
+
+// Bubbles report whether the event bubbles up through
+// the tree.
+// https://developer.mozilla.org/en-US/docs/Web/API/Event/bubbles
+func (ev *event) Bubbles() bool {
+	return ev.bubbles
+}
+
+// Cancelable report whether the event can be canceled.
+// https://developer.mozilla.org/en-US/docs/Web/API/Event/cancelable
+func (ev *event) Cancelable() bool {
+	return ev.cancelable
+}
+
+// CurrentTarget return the target whose listeners are
+// being invoked.
+// https://developer.mozilla.org/en-US/docs/Web/API/Event/currentTarget
+func (ev *event) CurrentTarget() EventTarget {
+	return ev.currentTarget
+}
+
+// DefaultPrevented report whether PreventDefault was
+// called on a cancelable event.
+// https://developer.mozilla.org/en-US/docs/Web/API/Event/defaultPrevented
+func (ev *event) DefaultPrevented() bool {
+	return ev.canceled
+}
+
+// EventPhase return the phase of the event flow being
+// processed.
+// https://developer.mozilla.org/en-US/docs/Web/API/Event/eventPhase
+func (ev *event) EventPhase() int {
+	return ev.eventPhase
+}
+
+// IsTrusted report whether the event was dispatched by
+// GOM rather than by DispatchEvent.
+// https://developer.mozilla.org/en-US/docs/Web/API/Event/isTrusted
+func (ev *event) IsTrusted() bool {
+	return ev.isTrusted
+}
+
+// Target return the target to which the event was
+// dispatched.
+// https://developer.mozilla.org/en-US/docs/Web/API/Event/target
+func (ev *event) Target() EventTarget {
+	return ev.target
+}
+
+// TimeStamp return the time at which the event was
+// created.
+// https://developer.mozilla.org/en-US/docs/Web/API/Event/timeStamp
+func (ev *event) TimeStamp() time.Time {
+	return ev.timeStamp
+}
+
+// Type return the type of the event.
+// https://developer.mozilla.org/en-US/docs/Web/API/Event/type
+func (ev *event) Type() string {
+	return ev.eventType
+}
+
+/*****************************************************
+ ********************* Methods ***********************
+ *****************************************************/
+// ANCHOR Methods
+
+// ComposedPath return the propagation path of the event,
+// from the target to the root, or nil if the event is
+// not being dispatched.
+// https://developer.mozilla.org/en-US/docs/Web/API/Event/composedPath
+func (ev *event) ComposedPath() []EventTarget {
+	if ev.path == nil {
+		return nil
+	}
+
+	path := make([]EventTarget, len(ev.path))
+	copy(path, ev.path)
+
+	return path
+}
+
+// InitEvent initialize an event created with
+// Document.CreateEvent. It is a no-op if the event is
+// being dispatched.
+// https://developer.mozilla.org/en-US/docs/Web/API/Event/initEvent
+func (ev *event) InitEvent(eventType string, bubbles, cancelable bool) {
+	if ev.dispatching {
+		return
+	}
+
+	ev.initEvent(eventType, bubbles, cancelable)
+}
+
+// PreventDefault cancel the event if it is cancelable.
+// It has no effect in a passive listener.
+// https://developer.mozilla.org/en-US/docs/Web/API/Event/preventDefault
+func (ev *event) PreventDefault() {
+	if ev.cancelable && !ev.inPassiveListener {
+		ev.canceled = true
+	}
+}
+
+// StopImmediatePropagation prevents the other listeners
+// of the event from being called.
+// https://developer.mozilla.org/en-US/docs/Web/API/Event/stopImmediatePropagation
+func (ev *event) StopImmediatePropagation() {
+	ev.stopPropagation = true
+	ev.stopImmediatePropagation = true
+}
+
+// StopPropagation prevents the propagation of the event
+// to the next targets of its path.
+// https://developer.mozilla.org/en-US/docs/Web/API/Event/stopPropagation
+func (ev *event) StopPropagation() {
+	ev.stopPropagation = true
+}
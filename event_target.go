@@ -0,0 +1,333 @@
+package gom
+
+import (
+	"reflect"
+
+	e "github.com/negrel/gom/exception"
+)
+
+// EventTarget interface is implemented by the objects
+// that can receive events and have listeners for them.
+// Every Node is an EventTarget.
+// https://developer.mozilla.org/en-US/docs/Web/API/EventTarget
+// https://dom.spec.whatwg.org/#interface-eventtarget
+type EventTarget interface {
+	/* Private */
+	eventListeners() []*eventListener
+	removeEventListener(listener *eventListener)
+	/* METHODS */
+	AddEventListener(eventType string, listener EventListener, options AddEventListenerOptions)
+	DispatchEvent(event Event) (bool, e.Exception)
+	RemoveEventListener(eventType string, listener EventListener, options EventListenerOptions)
+}
+
+// EventListener is the interface of the objects handling
+// the events dispatched to an EventTarget. Listeners are
+// compared by identity, use NewEventListener to create a
+// listener from a function. Listeners of an uncomparable
+// type, such as a func type, are always distinct: each
+// registration adds a new listener that can't be removed.
+// https://developer.mozilla.org/en-US/docs/Web/API/EventListener
+// https://dom.spec.whatwg.org/#callbackdef-eventlistener
+type EventListener interface {
+	HandleEvent(event Event)
+}
+
+// EventListenerOptions contains the options of the
+// RemoveEventListener method.
+// https://dom.spec.whatwg.org/#dictdef-eventlisteneroptions
+type EventListenerOptions struct {
+	Capture bool
+}
+
+// AddEventListenerOptions contains the options of the
+// AddEventListener method.
+// https://dom.spec.whatwg.org/#dictdef-addeventlisteneroptions
+type AddEventListenerOptions struct {
+	Capture bool
+	Once    bool
+	Passive bool
+}
+
+var _ EventTarget = &eventTarget{}
+var _ EventListener = &eventListenerFunc{}
+
+type eventTarget struct {
+	// self is the EventTarget embedding this eventTarget.
+	self      EventTarget
+	listeners []*eventListener
+}
+
+// eventListener is an entry of the listener list of an
+// EventTarget.
+// https://dom.spec.whatwg.org/#concept-event-listener
+type eventListener struct {
+	eventType string
+	callback  EventListener
+	capture   bool
+	once      bool
+	passive   bool
+	removed   bool
+}
+
+type eventListenerFunc struct {
+	fn func(event Event)
+}
+
+// NewEventTarget return a new EventTarget without parent.
+// https://developer.mozilla.org/en-US/docs/Web/API/EventTarget/EventTarget
+func NewEventTarget() EventTarget {
+	et := &eventTarget{}
+	et.self = et
+
+	return et
+}
+
+// NewEventListener return a new EventListener calling
+// the given function.
+func NewEventListener(fn func(event Event)) EventListener {
+	return &eventListenerFunc{fn: fn}
+}
+
+// HandleEvent call the listener function.
+func (l *eventListenerFunc) HandleEvent(event Event) {
+	l.fn(event)
+}
+
+// sameListener report whether a and b are the same
+// listener. Listeners of uncomparable types are never the
+// same.
+func sameListener(a, b EventListener) bool {
+	typ := reflect.TypeOf(a)
+	if typ == nil || typ != reflect.TypeOf(b) || !typ.Comparable() {
+		return false
+	}
+
+	return a == b
+}
+
+func (et *eventTarget) eventListeners() []*eventListener {
+	return et.listeners
+}
+
+func (et *eventTarget) removeEventListener(listener *eventListener) {
+	listener.removed = true
+
+	for i, l := range et.listeners {
+		if l == listener {
+			et.listeners = append(et.listeners[:i:i], et.listeners[i+1:]...)
+			return
+		}
+	}
+}
+
+// activationBehavior contains the steps run when an
+// element is activated by a click event. The legacy
+// steps run before the dispatch and when the event was
+// canceled.
+// https://dom.spec.whatwg.org/#eventtarget-activation-behavior
+type activationBehavior struct {
+	activation               func(event Event)
+	legacyPreActivation      func()
+	legacyCanceledActivation func()
+}
+
+// activatable is implemented by the targets that may have
+// an activation behavior.
+type activatable interface {
+	activationBehavior() *activationBehavior
+}
+
+// activationBehaviorOf return the activation behavior of
+// the target or nil if it has none.
+func activationBehaviorOf(target EventTarget) *activationBehavior {
+	if a, ok := target.(activatable); ok {
+		return a.activationBehavior()
+	}
+
+	return nil
+}
+
+// parentTarget return the parent of the target in the
+// event path, the parent node for nodes.
+// https://dom.spec.whatwg.org/#get-the-parent
+func parentTarget(target EventTarget) EventTarget {
+	if node, ok := target.(Node); ok {
+		if parent := node.ParentNode(); parent != nil {
+			return parent
+		}
+	}
+
+	return nil
+}
+
+// dispatchEvent dispatch the event to the target through
+// its capture, target and bubble phases. It return false
+// if the event was canceled.
+// https://dom.spec.whatwg.org/#concept-event-dispatch
+func dispatchEvent(target EventTarget, event Event) bool {
+	ev := event.state()
+	ev.dispatching = true
+	ev.target = target
+
+	// Click events activate the target or the first
+	// ancestor having an activation behavior.
+	_, isMouseEvent := event.(MouseEvent)
+	isActivationEvent := isMouseEvent && ev.eventType == "click"
+	var activation *activationBehavior
+
+	// Propagation path, from the target to the root.
+	for t := target; t != nil; t = parentTarget(t) {
+		ev.path = append(ev.path, t)
+
+		if isActivationEvent && activation == nil && (t == target || ev.bubbles) {
+			activation = activationBehaviorOf(t)
+		}
+	}
+
+	if activation != nil && activation.legacyPreActivation != nil {
+		activation.legacyPreActivation()
+	}
+
+	for i := len(ev.path) - 1; i >= 0; i-- {
+		if i == 0 {
+			ev.eventPhase = EventPhaseAtTarget
+		} else {
+			ev.eventPhase = EventPhaseCapturing
+		}
+
+		invokeListeners(ev.path[i], event, true)
+	}
+
+	for i, t := range ev.path {
+		if i == 0 {
+			ev.eventPhase = EventPhaseAtTarget
+		} else if ev.bubbles {
+			ev.eventPhase = EventPhaseBubbling
+		} else {
+			break
+		}
+
+		invokeListeners(t, event, false)
+	}
+
+	ev.eventPhase = EventPhaseNone
+	ev.currentTarget = nil
+
+	if activation != nil {
+		if !ev.canceled && activation.activation != nil {
+			activation.activation(event)
+		} else if ev.canceled && activation.legacyCanceledActivation != nil {
+			activation.legacyCanceledActivation()
+		}
+	}
+
+	ev.path = nil
+	ev.dispatching = false
+	ev.stopPropagation = false
+	ev.stopImmediatePropagation = false
+
+	return !ev.canceled
+}
+
+// fireEvent dispatch a new trusted event of the given
+// type to the target. It return false if the event was
+// canceled.
+// https://dom.spec.whatwg.org/#concept-event-fire
+func fireEvent(target EventTarget, eventType string, init EventInit) bool {
+	ev := newEvent(eventType, init)
+	ev.isTrusted = true
+
+	return dispatchEvent(target, ev)
+}
+
+// invokeListeners call the capture or non-capture
+// listeners of the target matching the event type.
+// https://dom.spec.whatwg.org/#concept-event-listener-invoke
+func invokeListeners(target EventTarget, event Event, capture bool) {
+	ev := event.state()
+	if ev.stopPropagation {
+		return
+	}
+
+	ev.currentTarget = target
+
+	// Listeners added during the invocation are not
+	// called.
+	listeners := append([]*eventListener(nil), target.eventListeners()...)
+
+	for _, listener := range listeners {
+		if listener.removed || listener.eventType != ev.eventType || listener.capture != capture {
+			continue
+		}
+
+		if listener.once {
+			target.removeEventListener(listener)
+		}
+
+		ev.inPassiveListener = listener.passive
+		listener.callback.HandleEvent(event)
+		ev.inPassiveListener = false
+
+		if ev.stopImmediatePropagation {
+			return
+		}
+	}
+}
+
+/*****************************************************
+ ********************* Methods ***********************
+ *****************************************************/
+// ANCHOR Methods
+
+// AddEventListener register the listener to be called
+// when an event of the given type is dispatched to the
+// target. Adding a registered listener is a no-op.
+// https://developer.mozilla.org/en-US/docs/Web/API/EventTarget/addEventListener
+// https://dom.spec.whatwg.org/#add-an-event-listener
+func (et *eventTarget) AddEventListener(eventType string, listener EventListener, options AddEventListenerOptions) {
+	if listener == nil {
+		return
+	}
+
+	for _, l := range et.listeners {
+		if l.eventType == eventType && sameListener(l.callback, listener) && l.capture == options.Capture {
+			return
+		}
+	}
+
+	et.listeners = append(et.listeners, &eventListener{
+		eventType: eventType,
+		callback:  listener,
+		capture:   options.Capture,
+		once:      options.Once,
+		passive:   options.Passive,
+	})
+}
+
+// DispatchEvent dispatch the event to the target and
+// return false if the event was canceled. An
+// InvalidStateError is returned if the event is already
+// being dispatched or isn't initialized.
+// https://developer.mozilla.org/en-US/docs/Web/API/EventTarget/dispatchEvent
+func (et *eventTarget) DispatchEvent(event Event) (bool, e.Exception) {
+	if ev := event.state(); ev.dispatching || !ev.initialized {
+		return false, e.New(e.InvalidStateError, "The event is already being dispatched or isn't initialized.")
+	}
+
+	event.state().isTrusted = false
+
+	return dispatchEvent(et.self, event), nil
+}
+
+// RemoveEventListener remove the listener registered
+// with the given type and capture option.
+// https://developer.mozilla.org/en-US/docs/Web/API/EventTarget/removeEventListener
+func (et *eventTarget) RemoveEventListener(eventType string, listener EventListener, options EventListenerOptions) {
+	for _, l := range et.listeners {
+		if l.eventType == eventType && sameListener(l.callback, listener) && l.capture == options.Capture {
+			et.removeEventListener(l)
+			return
+		}
+	}
+}
@@ -0,0 +1,217 @@
+package gom
+
+import (
+	"fmt"
+	"testing"
+
+	e "github.com/negrel/gom/exception"
+)
+
+// eventLog return a listener factory appending the name
+// of the current target and the event phase to the log.
+func eventLog(log *[]string) func(name string) EventListener {
+	return func(name string) EventListener {
+		return NewEventListener(func(event Event) {
+			*log = append(*log, fmt.Sprintf("%v:%v", name, event.EventPhase()))
+		})
+	}
+}
+
+func TestDispatchEventPath(t *testing.T) {
+	doc := parseTestDocument(t, `<div><p><b>text</b></p></div>`)
+	div := doc.DocumentElement()
+	p := div.FirstElementChild()
+	b := p.FirstElementChild()
+
+	var log []string
+	listener := eventLog(&log)
+
+	for _, capture := range []bool{false, true} {
+		options := AddEventListenerOptions{Capture: capture}
+		doc.AddEventListener("ping", listener("doc"), options)
+		div.AddEventListener("ping", listener("div"), options)
+		p.AddEventListener("ping", listener("p"), options)
+		b.AddEventListener("ping", listener("b"), options)
+	}
+
+	var path []EventTarget
+	b.AddEventListener("ping", NewEventListener(func(event Event) {
+		path = event.ComposedPath()
+	}), AddEventListenerOptions{})
+
+	event := NewEvent("ping", EventInit{Bubbles: true})
+	if ok, err := b.DispatchEvent(event); !ok || err != nil {
+		t.Fatalf("DispatchEvent must return true, got %v (%v).", ok, err)
+	}
+
+	expected := []string{"doc:1", "div:1", "p:1", "b:2", "b:2", "p:3", "div:3", "doc:3"}
+	if !equalStrings(log, expected) {
+		t.Logf("The listeners must be called in capture, target and bubble order, got %v.", log)
+		t.Fail()
+	}
+
+	if len(path) != 4 || path[0] != b || path[3] != doc {
+		t.Logf("ComposedPath must return the path from the target to the root, got %v.", path)
+		t.Fail()
+	}
+
+	if event.Target() != b || event.CurrentTarget() != nil || event.EventPhase() != EventPhaseNone {
+		t.Log("The event must keep its target and be reset after the dispatch.")
+		t.Fail()
+	}
+
+	// Non-bubbling events
+	log = nil
+	b.DispatchEvent(NewEvent("ping", EventInit{}))
+	expected = []string{"doc:1", "div:1", "p:1", "b:2", "b:2"}
+	if !equalStrings(log, expected) {
+		t.Logf("Non-bubbling events must not reach the bubbling phase, got %v.", log)
+		t.Fail()
+	}
+}
+
+func TestDispatchEventStopPropagation(t *testing.T) {
+	doc := parseTestDocument(t, `<div><p></p></div>`)
+	div := doc.DocumentElement()
+	p := div.FirstElementChild()
+
+	var log []string
+	listener := eventLog(&log)
+
+	p.AddEventListener("ping", NewEventListener(func(event Event) {
+		event.StopPropagation()
+	}), AddEventListenerOptions{})
+	p.AddEventListener("ping", listener("p"), AddEventListenerOptions{})
+	div.AddEventListener("ping", listener("div"), AddEventListenerOptions{})
+
+	p.DispatchEvent(NewEvent("ping", EventInit{Bubbles: true}))
+	if !equalStrings(log, []string{"p:2"}) {
+		t.Logf("StopPropagation must call the current target listeners only, got %v.", log)
+		t.Fail()
+	}
+
+	log = nil
+	div.AddEventListener("pong", NewEventListener(func(event Event) {
+		event.StopImmediatePropagation()
+	}), AddEventListenerOptions{Capture: true})
+	div.AddEventListener("pong", listener("div"), AddEventListenerOptions{Capture: true})
+	p.AddEventListener("pong", listener("p"), AddEventListenerOptions{})
+
+	p.DispatchEvent(NewEvent("pong", EventInit{Bubbles: true}))
+	if len(log) != 0 {
+		t.Logf("StopImmediatePropagation must stop the dispatch, got %v.", log)
+		t.Fail()
+	}
+}
+
+func TestDispatchEventPreventDefault(t *testing.T) {
+	target := NewEventTarget()
+
+	target.AddEventListener("submit", NewEventListener(func(event Event) {
+		event.PreventDefault()
+	}), AddEventListenerOptions{})
+
+	if ok, _ := target.DispatchEvent(NewEvent("submit", EventInit{Cancelable: true})); ok {
+		t.Log("DispatchEvent must return false if the event was canceled.")
+		t.Fail()
+	}
+
+	if ok, _ := target.DispatchEvent(NewEvent("submit", EventInit{})); !ok {
+		t.Log("Non-cancelable events can't be canceled.")
+		t.Fail()
+	}
+
+	passive := NewEventTarget()
+	passive.AddEventListener("submit", NewEventListener(func(event Event) {
+		event.PreventDefault()
+	}), AddEventListenerOptions{Passive: true})
+
+	if ok, _ := passive.DispatchEvent(NewEvent("submit", EventInit{Cancelable: true})); !ok {
+		t.Log("PreventDefault must have no effect in a passive listener.")
+		t.Fail()
+	}
+}
+
+func TestEventListenerRegistration(t *testing.T) {
+	target := NewEventTarget()
+	calls := 0
+	listener := NewEventListener(func(Event) { calls++ })
+
+	target.AddEventListener("ping", listener, AddEventListenerOptions{})
+	// Registered listeners are ignored
+	target.AddEventListener("ping", listener, AddEventListenerOptions{})
+	target.AddEventListener("ping", listener, AddEventListenerOptions{Capture: true, Once: true})
+
+	target.DispatchEvent(NewEvent("ping", EventInit{}))
+	if calls != 2 {
+		t.Logf("The listener must be called once per registration, got %v calls.", calls)
+		t.Fail()
+	}
+
+	target.DispatchEvent(NewEvent("ping", EventInit{}))
+	if calls != 3 {
+		t.Logf("Once listeners must be removed after their first call, got %v calls.", calls)
+		t.Fail()
+	}
+
+	target.RemoveEventListener("ping", listener, EventListenerOptions{Capture: true})
+	target.DispatchEvent(NewEvent("ping", EventInit{}))
+	if calls != 4 {
+		t.Log("RemoveEventListener must match the capture option.")
+		t.Fail()
+	}
+
+	target.RemoveEventListener("ping", listener, EventListenerOptions{})
+	target.DispatchEvent(NewEvent("ping", EventInit{}))
+	if calls != 4 {
+		t.Log("Removed listeners must not be called.")
+		t.Fail()
+	}
+}
+
+// listenerFunc is an uncomparable EventListener.
+type listenerFunc func(event Event)
+
+func (fn listenerFunc) HandleEvent(event Event) {
+	fn(event)
+}
+
+func TestEventListenerUncomparable(t *testing.T) {
+	target := NewEventTarget()
+	calls := 0
+	first := listenerFunc(func(Event) { calls++ })
+	second := listenerFunc(func(Event) { calls += 10 })
+
+	target.AddEventListener("ping", first, AddEventListenerOptions{})
+	target.AddEventListener("ping", second, AddEventListenerOptions{})
+	target.DispatchEvent(NewEvent("ping", EventInit{}))
+
+	if calls != 11 {
+		t.Logf("Uncomparable listeners must be registered as distinct listeners, got %v.", calls)
+		t.Fail()
+	}
+
+	target.RemoveEventListener("ping", first, EventListenerOptions{})
+	target.DispatchEvent(NewEvent("ping", EventInit{}))
+
+	if calls != 22 {
+		t.Logf("Uncomparable listeners can't be removed, got %v.", calls)
+		t.Fail()
+	}
+}
+
+func TestDispatchEventErrors(t *testing.T) {
+	target := NewEventTarget()
+	event := NewEvent("ping", EventInit{})
+
+	var err e.Exception
+	target.AddEventListener("ping", NewEventListener(func(event Event) {
+		_, err = target.DispatchEvent(event)
+	}), AddEventListenerOptions{})
+
+	target.DispatchEvent(event)
+	if err == nil {
+		t.Log("Dispatching an event being dispatched must return an InvalidStateError.")
+		t.Fail()
+	}
+}
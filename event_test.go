@@ -0,0 +1,30 @@
+package gom
+
+import (
+	"testing"
+)
+
+func TestNewEvent(t *testing.T) {
+	event := NewEvent("ping", EventInit{Bubbles: true})
+
+	if event.Type() != "ping" || !event.Bubbles() || event.Cancelable() {
+		t.Log("The event must be initialized with the given type and options.")
+		t.Fail()
+	}
+
+	if event.Target() != nil || event.EventPhase() != EventPhaseNone || event.ComposedPath() != nil {
+		t.Log("An event not dispatched must have no target, phase or path.")
+		t.Fail()
+	}
+
+	if event.TimeStamp().IsZero() || event.IsTrusted() {
+		t.Log("An event must have a creation time and not be trusted.")
+		t.Fail()
+	}
+
+	event.PreventDefault()
+	if event.DefaultPrevented() {
+		t.Log("PreventDefault must have no effect on non-cancelable events.")
+		t.Fail()
+	}
+}
@@ -1,5 +1,7 @@
 package gom
 
+import "strings"
+
 // GOMLCollection is live collection of elements
 // https://developer.mozilla.org/en-US/docs/Web/API/HTMLCollection
 // https://dom.spec.whatwg.org/#htmlcollection
@@ -8,20 +10,130 @@ type GOMLCollection interface {
 	Length() int
 	/* METHODS */
 	Item(int) Element
+	Values() []Element // Not part of DOM specification
 }
 
 var _ GOMLCollection = &gomlCollection{}
 
 type gomlCollection struct {
-	list []Element
+	root Node
+	// walk call its function for the nodes of root
+	// that may be part of the collection.
+	walk func(root Node, fn func(Node) bool) bool
+	// filter report whether a descendant element of
+	// root is part of the collection.
+	filter func(Element) bool
+	// list is the cached collection, valid as long as
+	// the root version is listVersion.
+	list        []Element
+	listVersion uint64
+	valid       bool
+}
+
+// newGOMLCollection return a live collection of the
+// descendant elements of root accepted by the filter.
+func newGOMLCollection(root Node, filter func(Element) bool) GOMLCollection {
+	return &gomlCollection{
+		root:   root,
+		walk:   descendants,
+		filter: filter,
+		list:   []Element{},
+	}
 }
 
-func newGOMLCollection() GOMLCollection {
+// newChildrenCollection return a live collection of the
+// child elements of root.
+func newChildrenCollection(root Node) GOMLCollection {
 	return &gomlCollection{
+		root: root,
+		walk: func(root Node, fn func(Node) bool) bool {
+			for child := root.FirstChild(); child != nil; child = child.NextSibling() {
+				if !fn(child) {
+					return false
+				}
+			}
+
+			return true
+		},
+		filter: func(Element) bool {
+			return true
+		},
 		list: []Element{},
 	}
 }
 
+// elementsByTagName return a live collection of the
+// descendant elements of root with the given tag name
+// or all elements for "*".
+// https://dom.spec.whatwg.org/#concept-getelementsbytagname
+func elementsByTagName(root Node, tagName string) GOMLCollection {
+	if tagName == "*" {
+		return newGOMLCollection(root, func(Element) bool {
+			return true
+		})
+	}
+
+	tagName = strings.ToLower(tagName)
+
+	return newGOMLCollection(root, func(el Element) bool {
+		return el.TagName() == tagName
+	})
+}
+
+// elementsByClassName return a live collection of the
+// descendant elements of root having all the given
+// space-separated class names.
+// https://dom.spec.whatwg.org/#concept-getelementsbyclassname
+func elementsByClassName(root Node, classNames string) GOMLCollection {
+	classes := strings.Fields(classNames)
+
+	return newGOMLCollection(root, func(el Element) bool {
+		if len(classes) == 0 {
+			return false
+		}
+
+		elClasses := el.ClassList()
+		for _, class := range classes {
+			if !containsString(elClasses, class) {
+				return false
+			}
+		}
+
+		return true
+	})
+}
+
+// containsString report whether list contains str.
+func containsString(list []string, str string) bool {
+	for _, s := range list {
+		if s == str {
+			return true
+		}
+	}
+
+	return false
+}
+
+// update recompute the collection if the root subtree
+// changed since the last computation.
+func (c *gomlCollection) update() {
+	if c.valid && c.listVersion == c.root.version() {
+		return
+	}
+
+	c.list = c.list[:0]
+	c.walk(c.root, func(n Node) bool {
+		if el, isElement := n.(Element); isElement && c.filter(el) {
+			c.list = append(c.list, el)
+		}
+
+		return true
+	})
+
+	c.listVersion = c.root.version()
+	c.valid = true
+}
+
 /*****************************************************
  **************** Getters & Setters ******************
  *****************************************************/
@@ -30,6 +142,8 @@ func newGOMLCollection() GOMLCollection {
 // Length method return the number of elements in
 // the collection
 func (c *gomlCollection) Length() int {
+	c.update()
+
 	return len(c.list)
 }
 
@@ -47,3 +161,14 @@ func (c *gomlCollection) Item(index int) Element {
 	}
 	return nil
 }
+
+// Values return a snapshot of the elements of the
+// collection.
+func (c *gomlCollection) Values() []Element {
+	c.update()
+
+	values := make([]Element, len(c.list))
+	copy(values, c.list)
+
+	return values
+}
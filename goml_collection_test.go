@@ -0,0 +1,80 @@
+package gom
+
+import (
+	"testing"
+)
+
+func TestGetElementsByTagName(t *testing.T) {
+	doc := parseTestDocument(t, `<div><p>1</p><section><p>2</p></section></div>`)
+	div := doc.DocumentElement()
+
+	paragraphs := doc.GetElementsByTagName("P")
+	if paragraphs.Length() != 2 {
+		t.Fatalf("Document must contain 2 paragraphs, got %v.", paragraphs.Length())
+	}
+
+	// Checking that the collection is live
+	p := doc.CreateElement("p")
+	div.AppendChild(p)
+
+	if paragraphs.Length() != 3 || !paragraphs.Item(2).IsSameNode(p) {
+		t.Log("Appended paragraph must be the last item of the live collection.")
+		t.Fail()
+	}
+
+	section := paragraphs.Item(1).ParentNode()
+	div.RemoveChild(section)
+
+	if paragraphs.Length() != 2 {
+		t.Logf("Collection must not contain removed paragraph, got %v items.", paragraphs.Length())
+		t.Fail()
+	}
+
+	// Nested modification
+	p.AppendChild(doc.CreateElement("p"))
+	if paragraphs.Length() != 3 {
+		t.Logf("Collection must contain the nested paragraph, got %v items.", paragraphs.Length())
+		t.Fail()
+	}
+
+	// Universal tag name
+	if all := div.GetElementsByTagName("*"); all.Length() != 3 {
+		t.Logf("Div must contain 3 elements, got %v.", all.Length())
+		t.Fail()
+	}
+
+	if item := paragraphs.Item(3); item != nil {
+		t.Log("Item out of range must return nil.")
+		t.Fail()
+	}
+}
+
+func TestGetElementsByClassName(t *testing.T) {
+	doc := parseTestDocument(t, `<div><p class="a b">1</p><p class="b">2</p><p class="a">3</p></div>`)
+
+	ab := doc.GetElementsByClassName(" b  a ")
+	if ab.Length() != 1 || ab.Item(0).FirstChild().(Text).Data() != "1" {
+		t.Log("Collection of \"a b\" classes must contain the first paragraph only.")
+		t.Fail()
+	}
+
+	// Checking that the collection reflect attribute changes
+	second := doc.DocumentElement().GetElementsByClassName("b").Item(1)
+	second.SetClassName("a b c")
+
+	if ab.Length() != 2 || !ab.Item(1).IsSameNode(second) {
+		t.Log("Collection must contain the element whose class changed.")
+		t.Fail()
+	}
+
+	second.GetAttribute("class").SetValue("c")
+	if ab.Length() != 1 {
+		t.Log("Collection must not contain the element whose class attribute value changed.")
+		t.Fail()
+	}
+
+	if empty := doc.GetElementsByClassName("  "); empty.Length() != 0 {
+		t.Log("Collection of no class name must be empty.")
+		t.Fail()
+	}
+}
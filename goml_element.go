@@ -1,5 +1,7 @@
 package gom
 
+import "strings"
+
 // GOMLElement interface represents any GOML element
 type GOMLElement interface {
 	/* EMBEDDED INTERFACE */
@@ -19,6 +21,33 @@ var _ Node = &gomlElement{}
 
 type gomlElement struct {
 	*element
+	clickInProgress bool
+}
+
+// disableableElements contains the form controls whose
+// disabled attribute makes them ignore clicks.
+// https://html.spec.whatwg.org/multipage/semantics-other.html#concept-element-disabled
+var disableableElements = map[string]bool{
+	"button":   true,
+	"fieldset": true,
+	"input":    true,
+	"optgroup": true,
+	"option":   true,
+	"select":   true,
+	"textarea": true,
+}
+
+// createGOMLElement return the GOML element corresponding
+// to the given tag name.
+func createGOMLElement(tagName string) GOMLElement {
+	if strings.ToLower(tagName) == "span" {
+		return createGOMLSpanElement()
+	}
+
+	e := &gomlElement{element: &element{}}
+	e.init(e, tagName)
+
+	return e
 }
 
 /*****************************************************
@@ -58,5 +87,75 @@ func (e *gomlElement) Style() interface{} {
  *****************************************************/
 // ANCHOR Methods
 
-// Click handle click event a GOML element.
-func (e *gomlElement) Click() {}
+// activationBehavior return the behavior of the element
+// when activated by a click or nil if it has none. A
+// checkbox toggles its checked attribute and a link with
+// an href fires a "navigate" event, GOM having no browsing
+// context to follow it.
+// https://html.spec.whatwg.org/multipage/dom.html#activation-behaviour
+func (e *gomlElement) activationBehavior() *activationBehavior {
+	self := e.node.self
+
+	switch e.TagName() {
+	case "input":
+		if typ := e.GetAttribute("type"); typ == nil || strings.ToLower(typ.Value()) != "checkbox" {
+			return nil
+		}
+
+		var checked bool
+		toggle := func() {
+			if e.HasAttribute("checked") {
+				e.RemoveAttribute("checked")
+			} else {
+				e.SetAttribute("checked", "")
+			}
+		}
+
+		return &activationBehavior{
+			legacyPreActivation: func() {
+				checked = e.HasAttribute("checked")
+				toggle()
+			},
+			legacyCanceledActivation: func() {
+				if e.HasAttribute("checked") != checked {
+					toggle()
+				}
+			},
+			activation: func(Event) {
+				fireEvent(self, "input", EventInit{Bubbles: true})
+				fireEvent(self, "change", EventInit{Bubbles: true})
+			},
+		}
+
+	case "a":
+		if !e.HasAttribute("href") {
+			return nil
+		}
+
+		return &activationBehavior{
+			activation: func(Event) {
+				fireEvent(self, "navigate", EventInit{Bubbles: true})
+			},
+		}
+	}
+
+	return nil
+}
+
+// Click dispatch a "click" MouseEvent to the element and run
+// the activation behavior of the element, or of its first
+// ancestor having one, unless the event was canceled. It is a
+// no-op for disabled form controls.
+// https://developer.mozilla.org/en-US/docs/Web/API/HTMLElement/click
+// https://html.spec.whatwg.org/multipage/interaction.html#dom-click
+func (e *gomlElement) Click() {
+	if e.clickInProgress || disableableElements[e.TagName()] && e.HasAttribute("disabled") {
+		return
+	}
+
+	e.clickInProgress = true
+	dispatchEvent(e.node.self, NewMouseEvent("click", MouseEventInit{
+		EventInit: EventInit{Bubbles: true, Cancelable: true},
+	}))
+	e.clickInProgress = false
+}
@@ -0,0 +1,119 @@
+package gom
+
+import (
+	"testing"
+)
+
+func TestGOMLElementClick(t *testing.T) {
+	doc := parseTestDocument(t, `<div><button>Ok</button></div>`)
+	div := doc.DocumentElement()
+	button := div.FirstElementChild().(GOMLElement)
+
+	var clicks []Event
+	div.AddEventListener("click", NewEventListener(func(event Event) {
+		clicks = append(clicks, event)
+		// Clicking during a click is a no-op
+		button.Click()
+	}), AddEventListenerOptions{})
+
+	button.Click()
+
+	if len(clicks) != 1 {
+		t.Fatalf("Click must dispatch a single bubbling click event, got %v.", len(clicks))
+	}
+
+	event, ok := clicks[0].(MouseEvent)
+	if !ok || event.Type() != "click" || !event.Cancelable() || event.IsTrusted() || event.Target() != button {
+		t.Log("Click must dispatch an untrusted cancelable MouseEvent to the element.")
+		t.Fail()
+	}
+
+	button.SetAttribute("disabled", "")
+	button.Click()
+	if len(clicks) != 1 {
+		t.Log("Click must be a no-op for disabled elements.")
+		t.Fail()
+	}
+
+	// Only form controls can be disabled
+	div.SetAttribute("disabled", "")
+	div.(GOMLElement).Click()
+	if len(clicks) != 2 {
+		t.Log("The disabled attribute must be ignored on elements other than form controls.")
+		t.Fail()
+	}
+}
+
+func TestGOMLElementClickCheckbox(t *testing.T) {
+	doc := parseTestDocument(t, `<div><input type="checkbox"></div>`)
+	div := doc.DocumentElement()
+	checkbox := div.FirstElementChild().(GOMLElement)
+
+	var events []string
+	listener := NewEventListener(func(event Event) {
+		events = append(events, event.Type())
+	})
+	div.AddEventListener("input", listener, AddEventListenerOptions{})
+	div.AddEventListener("change", listener, AddEventListenerOptions{})
+
+	var checkedDuringClick bool
+	checkbox.AddEventListener("click", NewEventListener(func(Event) {
+		checkedDuringClick = checkbox.HasAttribute("checked")
+	}), AddEventListenerOptions{})
+
+	checkbox.Click()
+
+	if !checkbox.HasAttribute("checked") || !checkedDuringClick {
+		t.Log("Clicking a checkbox must toggle its checked attribute before the listeners.")
+		t.Fail()
+	}
+
+	if !equalStrings(events, []string{"input", "change"}) {
+		t.Logf("Clicking a checkbox must fire the input and change events, got %v.", events)
+		t.Fail()
+	}
+
+	// Canceled click
+	events = nil
+	prevent := NewEventListener(func(event Event) {
+		event.PreventDefault()
+	})
+	div.AddEventListener("click", prevent, AddEventListenerOptions{})
+
+	checkbox.Click()
+
+	if !checkbox.HasAttribute("checked") || checkedDuringClick {
+		t.Log("A canceled click must restore the checked attribute.")
+		t.Fail()
+	}
+
+	if len(events) != 0 {
+		t.Logf("A canceled click must not fire the input and change events, got %v.", events)
+		t.Fail()
+	}
+}
+
+func TestGOMLElementClickLink(t *testing.T) {
+	doc := parseTestDocument(t, `<div><a href="/home"><span>Home</span></a><a>No href</a></div>`)
+	div := doc.DocumentElement()
+	link := div.FirstElementChild()
+	span := link.FirstElementChild().(GOMLElement)
+
+	var targets []EventTarget
+	div.AddEventListener("navigate", NewEventListener(func(event Event) {
+		targets = append(targets, event.Target())
+	}), AddEventListenerOptions{})
+
+	// Clicking a link descendant follows the link
+	span.Click()
+	if len(targets) != 1 || targets[0] != link {
+		t.Logf("Clicking a link must fire a navigate event at the link, got %v.", targets)
+		t.Fail()
+	}
+
+	div.LastElementChild().(GOMLElement).Click()
+	if len(targets) != 1 {
+		t.Log("A link without href must not be followed.")
+		t.Fail()
+	}
+}
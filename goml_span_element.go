@@ -10,6 +10,13 @@ var _ GOMLElement = &GOMLSpanElement{}
 var _ Element = &GOMLSpanElement{}
 var _ Node = &GOMLSpanElement{}
 
+func createGOMLSpanElement() *GOMLSpanElement {
+	s := &GOMLSpanElement{gomlElement{element: &element{}}}
+	s.init(s, "span")
+
+	return s
+}
+
 /*****************************************************
  **************** Getters & Setters ******************
  *****************************************************/
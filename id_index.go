@@ -0,0 +1,90 @@
+package gom
+
+// idIndex map the ids to the connected elements
+// of a document.
+type idIndex struct {
+	ids map[string][]Element
+}
+
+func newIdIndex() *idIndex {
+	return &idIndex{
+		ids: make(map[string][]Element),
+	}
+}
+
+// add register the element under the given id.
+func (index *idIndex) add(id string, el Element) {
+	if id == "" {
+		return
+	}
+
+	index.ids[id] = append(index.ids[id], el)
+}
+
+// remove unregister the element from the given id.
+func (index *idIndex) remove(id string, el Element) {
+	elements := index.ids[id]
+
+	for i, registered := range elements {
+		if registered == el {
+			elements = append(elements[:i], elements[i+1:]...)
+			break
+		}
+	}
+
+	if len(elements) == 0 {
+		delete(index.ids, id)
+	} else {
+		index.ids[id] = elements
+	}
+}
+
+// addSubtree register root and its descendants elements.
+func (index *idIndex) addSubtree(root Node) {
+	index.walk(root, index.add)
+}
+
+// removeSubtree unregister root and its descendants
+// elements.
+func (index *idIndex) removeSubtree(root Node) {
+	index.walk(root, index.remove)
+}
+
+// walk call fn for root and each of its descendants
+// elements having an id.
+func (index *idIndex) walk(root Node, fn func(string, Element)) {
+	visit := func(n Node) bool {
+		if el, isElement := n.(Element); isElement {
+			if id := el.Id(); id != nil {
+				fn(id.Value(), el)
+			}
+		}
+
+		return true
+	}
+
+	visit(root)
+	descendants(root, visit)
+}
+
+// get return the first element in tree order with the
+// given id.
+func (index *idIndex) get(id string) Element {
+	var first Element
+
+	for _, el := range index.ids[id] {
+		if first == nil || precedes(el, first) {
+			first = el
+		}
+	}
+
+	return first
+}
+
+// precedes report whether a is before b in tree order.
+// It return false if the nodes are not in the same tree.
+func precedes(a, b Node) bool {
+	position := a.CompareDocumentPosition(b)
+
+	return position&DocumentPositionDisconnected == 0 && position&DocumentPositionFollowing != 0
+}
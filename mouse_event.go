@@ -0,0 +1,127 @@
+package gom
+
+// MouseEvent interface represents an event that occurs
+// due to the user interacting with a pointing device.
+// https://developer.mozilla.org/en-US/docs/Web/API/MouseEvent
+// https://w3c.github.io/uievents/#interface-mouseevent
+type MouseEvent interface {
+	/* EMBEDDED INTERFACE */
+	Event
+	/* GETTERS & SETTERS (props) */
+	AltKey() bool
+	Button() int
+	Buttons() int
+	ClientX() int
+	ClientY() int
+	CtrlKey() bool
+	MetaKey() bool
+	ScreenX() int
+	ScreenY() int
+	ShiftKey() bool
+}
+
+// MouseEventInit contains the options of NewMouseEvent.
+// https://w3c.github.io/uievents/#dictdef-mouseeventinit
+type MouseEventInit struct {
+	EventInit
+	AltKey   bool
+	Button   int
+	Buttons  int
+	ClientX  int
+	ClientY  int
+	CtrlKey  bool
+	MetaKey  bool
+	ScreenX  int
+	ScreenY  int
+	ShiftKey bool
+}
+
+var _ MouseEvent = &mouseEvent{}
+
+type mouseEvent struct {
+	*event
+	init MouseEventInit
+}
+
+// NewMouseEvent return a new MouseEvent of the given type.
+// https://developer.mozilla.org/en-US/docs/Web/API/MouseEvent/MouseEvent
+func NewMouseEvent(eventType string, init MouseEventInit) MouseEvent {
+	return &mouseEvent{
+		event: newEvent(eventType, init.EventInit),
+		init:  init,
+	}
+}
+
+/*****************************************************
+ **************** Getters & Setters ******************
+ *****************************************************/
+// ANCHOR Getters & Setters
+
+// AltKey report whether the alt key was down when the
+// event was fired.
+// https://developer.mozilla.org/en-US/docs/Web/API/MouseEvent/altKey
+func (me *mouseEvent) AltKey() bool {
+	return me.init.AltKey
+}
+
+// Button return the button pressed to fire the event.
+// https://developer.mozilla.org/en-US/docs/Web/API/MouseEvent/button
+func (me *mouseEvent) Button() int {
+	return me.init.Button
+}
+
+// Buttons return the bitmask of the buttons pressed when
+// the event was fired.
+// https://developer.mozilla.org/en-US/docs/Web/API/MouseEvent/buttons
+func (me *mouseEvent) Buttons() int {
+	return me.init.Buttons
+}
+
+// ClientX return the horizontal coordinate of the event
+// in the viewport.
+// https://developer.mozilla.org/en-US/docs/Web/API/MouseEvent/clientX
+func (me *mouseEvent) ClientX() int {
+	return me.init.ClientX
+}
+
+// ClientY return the vertical coordinate of the event
+// in the viewport.
+// https://developer.mozilla.org/en-US/docs/Web/API/MouseEvent/clientY
+func (me *mouseEvent) ClientY() int {
+	return me.init.ClientY
+}
+
+// CtrlKey report whether the control key was down when
+// the event was fired.
+// https://developer.mozilla.org/en-US/docs/Web/API/MouseEvent/ctrlKey
+func (me *mouseEvent) CtrlKey() bool {
+	return me.init.CtrlKey
+}
+
+// MetaKey report whether the meta key was down when the
+// event was fired.
+// https://developer.mozilla.org/en-US/docs/Web/API/MouseEvent/metaKey
+func (me *mouseEvent) MetaKey() bool {
+	return me.init.MetaKey
+}
+
+// ScreenX return the horizontal coordinate of the event
+// on the screen.
+// https://developer.mozilla.org/en-US/docs/Web/API/MouseEvent/screenX
+func (me *mouseEvent) ScreenX() int {
+	return me.init.ScreenX
+}
+
+// ScreenY return the vertical coordinate of the event
+// on the screen.
+// https://developer.mozilla.org/en-US/docs/Web/API/MouseEvent/screenY
+func (me *mouseEvent) ScreenY() int {
+	return me.init.ScreenY
+}
+
+// ShiftKey report whether the shift key was down when
+// the event was fired.
+// https://developer.mozilla.org/en-US/docs/Web/API/MouseEvent/shiftKey
+func (me *mouseEvent) ShiftKey() bool {
+	return me.init.ShiftKey
+}
@@ -0,0 +1,519 @@
+package gom
+
+import (
+	e "github.com/negrel/gom/exception"
+)
+
+// MutationCallback is called by the MutationObserver
+// with the records queued since the last call.
+// https://dom.spec.whatwg.org/#callbackdef-mutationcallback
+type MutationCallback func(records []MutationRecord, observer MutationObserver)
+
+// MutationObserverInit contains the options of the
+// MutationObserver Observe method. Attributes is implied
+// by AttributeOldValue and AttributeFilter, CharacterData
+// by CharacterDataOldValue.
+// https://developer.mozilla.org/en-US/docs/Web/API/MutationObserverInit
+// https://dom.spec.whatwg.org/#dictdef-mutationobserverinit
+type MutationObserverInit struct {
+	ChildList             bool
+	Attributes            bool
+	CharacterData         bool
+	Subtree               bool
+	AttributeOldValue     bool
+	CharacterDataOldValue bool
+	AttributeFilter       []string
+}
+
+// MutationObserver interface provides the ability to watch
+// for changes being made to the tree. The records are
+// queued in the document of the mutated nodes, nothing is
+// delivered to the callback until the document
+// NotifyMutationObservers method is called. An observer
+// of several documents receives all its records at the
+// checkpoint of any of them.
+// https://developer.mozilla.org/en-US/docs/Web/API/MutationObserver
+// https://dom.spec.whatwg.org/#interface-mutationobserver
+type MutationObserver interface {
+	/* METHODS */
+	Disconnect()
+	Observe(target Node, options MutationObserverInit) e.Exception
+	TakeRecords() []MutationRecord
+}
+
+// The MutationRecord types.
+const (
+	MutationAttributes    = "attributes"
+	MutationCharacterData = "characterData"
+	MutationChildList     = "childList"
+)
+
+// MutationRecord represents an individual mutation.
+// https://developer.mozilla.org/en-US/docs/Web/API/MutationRecord
+// https://dom.spec.whatwg.org/#interface-mutationrecord
+type MutationRecord interface {
+	/* GETTERS & SETTERS (props) */
+	AddedNodes() NodeList
+	AttributeName() string
+	NextSibling() Node
+	OldValue() *string
+	PreviousSibling() Node
+	RemovedNodes() NodeList
+	Target() Node
+	Type() string
+}
+
+var _ MutationObserver = &mutationObserver{}
+var _ MutationRecord = &mutationRecord{}
+
+type mutationObserver struct {
+	callback MutationCallback
+	records  []MutationRecord
+	// targets are the nodes observed and transients the
+	// nodes with a transient registration.
+	targets    []Node
+	transients []Node
+	// documents are the documents in which the observer
+	// is listed.
+	documents []Document
+	// pending report whether the observer has records or
+	// transient registrations to process at the next
+	// checkpoint.
+	pending bool
+}
+
+// registeredObserver is a registration of an observer
+// on a node. Transient registrations are added to the
+// removed nodes of an observed subtree until the next
+// notification, source is the originating registration.
+// https://dom.spec.whatwg.org/#registered-observer
+type registeredObserver struct {
+	observer *mutationObserver
+	options  MutationObserverInit
+	source   *registeredObserver
+	// document is the document counting the registration.
+	document Document
+}
+
+// mutationObserverQueue contains the mutation observers
+// state of a document.
+type mutationObserverQueue struct {
+	// registrations is the number of registrations on the
+	// document nodes, records are queued only when it is
+	// positive.
+	registrations int
+	// observers are the observers listed in the document,
+	// in the order they were listed. They are notified in
+	// this order.
+	observers []*mutationObserver
+	// pending report whether an observer of the document
+	// must be notified at the next checkpoint.
+	pending bool
+}
+
+type mutationRecord struct {
+	recordType      string
+	target          Node
+	addedNodes      NodeList
+	removedNodes    NodeList
+	previousSibling Node
+	nextSibling     Node
+	attributeName   string
+	oldValue        *string
+}
+
+// NewMutationObserver return a new MutationObserver that
+// will call the given callback when notified.
+// https://developer.mozilla.org/en-US/docs/Web/API/MutationObserver/MutationObserver
+func NewMutationObserver(callback MutationCallback) MutationObserver {
+	return &mutationObserver{
+		callback: callback,
+	}
+}
+
+// notifyMutationObservers deliver the records queued in
+// the document to the callback of their observer, in the
+// order the observers were listed, until no record is
+// queued.
+// https://dom.spec.whatwg.org/#notify-mutation-observers
+func notifyMutationObservers(doc Document) {
+	queue := doc.mutationObserverQueue()
+
+	for queue.pending {
+		queue.pending = false
+		observers := append([]*mutationObserver(nil), queue.observers...)
+
+		for _, mo := range observers {
+			if !mo.pending {
+				continue
+			}
+
+			mo.pending = false
+			records := mo.TakeRecords()
+			mo.removeTransients()
+
+			if len(records) > 0 && mo.callback != nil {
+				mo.callback(records, mo)
+			}
+		}
+	}
+}
+
+// list add the observer to the observers of the document.
+func (mo *mutationObserver) list(doc Document) {
+	for _, listed := range mo.documents {
+		if listed == doc {
+			return
+		}
+	}
+
+	queue := doc.mutationObserverQueue()
+	queue.observers = append(queue.observers, mo)
+	mo.documents = append(mo.documents, doc)
+}
+
+// unlist remove the observer from the observers of its
+// documents.
+func (mo *mutationObserver) unlist() {
+	for _, doc := range mo.documents {
+		queue := doc.mutationObserverQueue()
+
+		for i, listed := range queue.observers {
+			if listed == mo {
+				queue.observers = append(queue.observers[:i:i], queue.observers[i+1:]...)
+				break
+			}
+		}
+	}
+
+	mo.documents = nil
+}
+
+// register add a registration of the observer on the node
+// counted by the node document.
+func (mo *mutationObserver) register(node Node, options MutationObserverInit, source *registeredObserver) {
+	doc := nodeDocument(node)
+	doc.mutationObserverQueue().registrations++
+	mo.list(doc)
+
+	node.setMutationObservers(append(node.mutationObservers(), &registeredObserver{
+		observer: mo,
+		options:  options,
+		source:   source,
+		document: doc,
+	}))
+}
+
+// schedule mark the observer to be notified at the next
+// checkpoint of the document.
+func (mo *mutationObserver) schedule(doc Document) {
+	mo.list(doc)
+	mo.pending = true
+	doc.mutationObserverQueue().pending = true
+}
+
+// enqueue append the record to the observer queue.
+func (mo *mutationObserver) enqueue(doc Document, record MutationRecord) {
+	mo.schedule(doc)
+	mo.records = append(mo.records, record)
+}
+
+// unregister remove the registrations of the observer
+// from the node matching the predicate.
+func (mo *mutationObserver) unregister(node Node, predicate func(*registeredObserver) bool) {
+	registrations := node.mutationObservers()
+	kept := registrations[:0]
+
+	for _, registered := range registrations {
+		if registered.observer == mo && predicate(registered) {
+			registered.document.mutationObserverQueue().registrations--
+			continue
+		}
+		kept = append(kept, registered)
+	}
+
+	node.setMutationObservers(kept)
+}
+
+// removeTransients remove the transient registrations of
+// the observer.
+func (mo *mutationObserver) removeTransients() {
+	for _, node := range mo.transients {
+		mo.unregister(node, func(registered *registeredObserver) bool {
+			return registered.source != nil
+		})
+	}
+
+	mo.transients = nil
+}
+
+// queueMutationRecord queue a record of the given type for
+// target to the interested observers. The old value is
+// given to the observers requesting it.
+// https://dom.spec.whatwg.org/#queue-a-mutation-record
+func queueMutationRecord(record *mutationRecord) {
+	doc := observedDocument(record.target)
+	if doc == nil {
+		return
+	}
+
+	var interested []*mutationObserver
+	oldValues := make(map[*mutationObserver]bool)
+
+	for node := record.target; node != nil; node = node.ParentNode() {
+		for _, registered := range node.mutationObservers() {
+			options := registered.options
+
+			if node != record.target && !options.Subtree {
+				continue
+			}
+
+			switch record.recordType {
+			case MutationAttributes:
+				if !options.Attributes ||
+					(options.AttributeFilter != nil && !containsString(options.AttributeFilter, record.attributeName)) {
+					continue
+				}
+			case MutationCharacterData:
+				if !options.CharacterData {
+					continue
+				}
+			case MutationChildList:
+				if !options.ChildList {
+					continue
+				}
+			}
+
+			mo := registered.observer
+			if _, ok := oldValues[mo]; !ok {
+				interested = append(interested, mo)
+			}
+
+			oldValues[mo] = oldValues[mo] ||
+				(record.recordType == MutationAttributes && options.AttributeOldValue) ||
+				(record.recordType == MutationCharacterData && options.CharacterDataOldValue)
+		}
+	}
+
+	for _, mo := range interested {
+		r := *record
+		if !oldValues[mo] {
+			r.oldValue = nil
+		}
+
+		mo.enqueue(doc, &r)
+	}
+}
+
+// queueTreeMutationRecord queue a childList record for
+// target.
+// https://dom.spec.whatwg.org/#queue-a-tree-mutation-record
+func queueTreeMutationRecord(target Node, addedNodes, removedNodes []Node, previousSibling, nextSibling Node) {
+	if observedDocument(target) == nil {
+		return
+	}
+
+	added, removed := newNodeList(), newNodeList()
+	added.list, removed.list = addedNodes, removedNodes
+
+	queueMutationRecord(&mutationRecord{
+		recordType:      MutationChildList,
+		target:          target,
+		addedNodes:      added,
+		removedNodes:    removed,
+		previousSibling: previousSibling,
+		nextSibling:     nextSibling,
+	})
+}
+
+// addTransientObservers register the subtree observers of
+// the parent inclusive ancestors on the removed child.
+// https://dom.spec.whatwg.org/#concept-node-remove
+func addTransientObservers(parent, child Node) {
+	doc := observedDocument(parent)
+	if doc == nil || nodeDocument(child) == nil {
+		return
+	}
+
+	for node := parent; node != nil; node = node.ParentNode() {
+		for _, registered := range node.mutationObservers() {
+			if !registered.options.Subtree {
+				continue
+			}
+
+			mo := registered.observer
+			// Transient registrations are removed at the
+			// next checkpoint.
+			mo.schedule(doc)
+
+			mo.register(child, registered.options, registered)
+			mo.transients = append(mo.transients, child)
+		}
+	}
+}
+
+// observedDocument return the document of the node if it
+// has mutation observers registrations, nil otherwise.
+func observedDocument(n Node) Document {
+	doc := nodeDocument(n)
+	if doc == nil || doc.mutationObserverQueue().registrations == 0 {
+		return nil
+	}
+
+	return doc
+}
+
+// adoptMutationObservers move the registrations on the
+// node to the given document.
+func adoptMutationObservers(node Node, doc Document) {
+	for _, registered := range node.mutationObservers() {
+		if registered.document == doc {
+			continue
+		}
+
+		registered.document.mutationObserverQueue().registrations--
+		doc.mutationObserverQueue().registrations++
+		registered.document = doc
+		registered.observer.list(doc)
+	}
+}
+
+/*****************************************************
+ **************** Getters & Setters ******************
+ *****************************************************/
+// ANCHOR Getters & Setters
+
+// AddedNodes return the nodes added by the mutation.
+// https://developer.mozilla.org/en-US/docs/Web/API/MutationRecord
+func (r *mutationRecord) AddedNodes() NodeList {
+	return r.addedNodes
+}
+
+// AttributeName return the name of the changed attribute
+// or an empty string.
+// https://developer.mozilla.org/en-US/docs/Web/API/MutationRecord
+func (r *mutationRecord) AttributeName() string {
+	return r.attributeName
+}
+
+// NextSibling return the next sibling of the added or
+// removed nodes.
+// https://developer.mozilla.org/en-US/docs/Web/API/MutationRecord
+func (r *mutationRecord) NextSibling() Node {
+	return r.nextSibling
+}
+
+// OldValue return the value of the attribute or the data
+// before the mutation. It is nil for childList records,
+// if the old value wasn't requested or if the attribute
+// was absent.
+// https://developer.mozilla.org/en-US/docs/Web/API/MutationRecord
+func (r *mutationRecord) OldValue() *string {
+	return r.oldValue
+}
+
+// PreviousSibling return the previous sibling of the
+// added or removed nodes.
+// https://developer.mozilla.org/en-US/docs/Web/API/MutationRecord
+func (r *mutationRecord) PreviousSibling() Node {
+	return r.previousSibling
+}
+
+// RemovedNodes return the nodes removed by the mutation.
+// https://developer.mozilla.org/en-US/docs/Web/API/MutationRecord
+func (r *mutationRecord) RemovedNodes() NodeList {
+	return r.removedNodes
+}
+
+// Target return the node affected by the mutation.
+// https://developer.mozilla.org/en-US/docs/Web/API/MutationRecord
+func (r *mutationRecord) Target() Node {
+	return r.target
+}
+
+// Type return the type of the mutation: "attributes",
+// "characterData" or "childList".
+// https://developer.mozilla.org/en-US/docs/Web/API/MutationRecord
+func (r *mutationRecord) Type() string {
+	return r.recordType
+}
+
+/*****************************************************
+ ********************* Methods ***********************
+ *****************************************************/
+// ANCHOR Methods
+
+// Disconnect stops the observer from receiving records
+// and discard the queued ones.
+// https://developer.mozilla.org/en-US/docs/Web/API/MutationObserver/disconnect
+func (mo *mutationObserver) Disconnect() {
+	all := func(*registeredObserver) bool { return true }
+
+	for _, node := range mo.targets {
+		mo.unregister(node, all)
+	}
+	for _, node := range mo.transients {
+		mo.unregister(node, all)
+	}
+
+	mo.unlist()
+	mo.targets, mo.transients, mo.records = nil, nil, nil
+}
+
+// Observe configures the observer to receive the records
+// of the target mutations matching the options. Observing
+// an already observed target replace its options.
+// https://developer.mozilla.org/en-US/docs/Web/API/MutationObserver/observe
+// https://dom.spec.whatwg.org/#dom-mutationobserver-observe
+func (mo *mutationObserver) Observe(target Node, options MutationObserverInit) e.Exception {
+	if target == nil {
+		return e.TypeError("The target node is nil.")
+	}
+
+	if nodeDocument(target) == nil {
+		return e.New(e.NotSupportedError, "The target node has no document.")
+	}
+
+	if options.AttributeOldValue || options.AttributeFilter != nil {
+		options.Attributes = true
+	}
+	if options.CharacterDataOldValue {
+		options.CharacterData = true
+	}
+
+	if !options.ChildList && !options.Attributes && !options.CharacterData {
+		return e.TypeError("The options must contain childList, attributes or characterData.")
+	}
+
+	for _, registered := range target.mutationObservers() {
+		if registered.observer != mo || registered.source != nil {
+			continue
+		}
+
+		// Removing the transient registrations of the
+		// previous options.
+		for _, node := range mo.transients {
+			mo.unregister(node, func(transient *registeredObserver) bool {
+				return transient.source == registered
+			})
+		}
+		registered.options = options
+
+		return nil
+	}
+
+	mo.register(target, options, nil)
+	mo.targets = append(mo.targets, target)
+
+	return nil
+}
+
+// TakeRecords return the queued records and empty the
+// queue.
+// https://developer.mozilla.org/en-US/docs/Web/API/MutationObserver/takeRecords
+func (mo *mutationObserver) TakeRecords() []MutationRecord {
+	records := mo.records
+	mo.records = nil
+
+	return records
+}
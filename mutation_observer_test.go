@@ -0,0 +1,336 @@
+package gom
+
+import (
+	"testing"
+)
+
+// recordTypes return the type of the records.
+func recordTypes(records []MutationRecord) []string {
+	types := make([]string, len(records))
+
+	for i, record := range records {
+		types[i] = record.Type()
+	}
+
+	return types
+}
+
+func TestMutationObserverChildList(t *testing.T) {
+	doc := parseTestDocument(t, `<div><p>a</p><p>b</p></div>`)
+	div := doc.DocumentElement()
+	first, second := div.FirstChild(), div.LastChild()
+
+	var batches [][]MutationRecord
+	observer := NewMutationObserver(func(records []MutationRecord, _ MutationObserver) {
+		batches = append(batches, records)
+	})
+	defer observer.Disconnect()
+
+	if err := observer.Observe(div, MutationObserverInit{ChildList: true}); err != nil {
+		t.Fatalf("Error while observing the node : %v", err)
+	}
+
+	span := doc.CreateElement("span")
+	div.AppendChild(span)
+	div.RemoveChild(first)
+	i := doc.CreateElement("i")
+	div.ReplaceChild(i, second)
+
+	if len(batches) != 0 {
+		t.Fatal("The records must not be delivered before the checkpoint.")
+	}
+
+	doc.NotifyMutationObservers()
+
+	if len(batches) != 1 || len(batches[0]) != 3 {
+		t.Fatalf("The records must be delivered in a single batch, got %v.", batches)
+	}
+	records := batches[0]
+
+	appended := records[0]
+	if !appended.Target().IsSameNode(div) || appended.AddedNodes().Length() != 1 ||
+		!appended.AddedNodes().Item(0).IsSameNode(span) || !appended.PreviousSibling().IsSameNode(second) ||
+		appended.NextSibling() != nil {
+		t.Log("AppendChild record must contain the added node and its siblings.")
+		t.Fail()
+	}
+
+	removed := records[1]
+	if removed.RemovedNodes().Length() != 1 || !removed.RemovedNodes().Item(0).IsSameNode(first) ||
+		removed.PreviousSibling() != nil || !removed.NextSibling().IsSameNode(second) {
+		t.Log("RemoveChild record must contain the removed node and its siblings.")
+		t.Fail()
+	}
+
+	replaced := records[2]
+	if !replaced.AddedNodes().Item(0).IsSameNode(i) || !replaced.RemovedNodes().Item(0).IsSameNode(second) ||
+		!replaced.NextSibling().IsSameNode(span) {
+		t.Log("ReplaceChild must queue a single record with the added and removed nodes.")
+		t.Fail()
+	}
+
+	doc.NotifyMutationObservers()
+	if len(batches) != 1 {
+		t.Log("The callback must not be called without records.")
+		t.Fail()
+	}
+}
+
+func TestMutationObserverDocuments(t *testing.T) {
+	first := parseTestDocument(t, `<div></div>`)
+	second := parseTestDocument(t, `<div></div>`)
+
+	var calls []string
+	observe := func(name string, doc Document) {
+		observer := NewMutationObserver(func([]MutationRecord, MutationObserver) {
+			calls = append(calls, name)
+		})
+		observer.Observe(doc.DocumentElement(), MutationObserverInit{ChildList: true})
+	}
+	observe("first", first)
+	observe("second", second)
+
+	first.DocumentElement().AppendChild(first.CreateElement("p"))
+	second.DocumentElement().AppendChild(second.CreateElement("p"))
+
+	first.NotifyMutationObservers()
+	if !equalStrings(calls, []string{"first"}) {
+		t.Logf("NotifyMutationObservers must only deliver the records of its document, got %v.", calls)
+		t.Fail()
+	}
+
+	second.NotifyMutationObservers()
+	if !equalStrings(calls, []string{"first", "second"}) {
+		t.Logf("Each document must deliver its own records, got %v.", calls)
+		t.Fail()
+	}
+
+	// Adopted nodes are observed in their new document
+	p := second.DocumentElement().FirstChild()
+	observer := NewMutationObserver(nil)
+	defer observer.Disconnect()
+	observer.Observe(p, MutationObserverInit{ChildList: true})
+
+	first.AdoptNode(p)
+	p.AppendChild(first.CreateElement("b"))
+
+	if len(observer.TakeRecords()) != 1 || second.mutationObserverQueue().registrations != 1 ||
+		first.mutationObserverQueue().registrations != 2 {
+		t.Log("The registrations of adopted nodes must move to their new document.")
+		t.Fail()
+	}
+}
+
+func TestMutationObserverFragment(t *testing.T) {
+	doc := parseTestDocument(t, `<div></div>`)
+	div := doc.DocumentElement()
+
+	fragment := doc.CreateDocumentFragment()
+	fragment.AppendChild(doc.CreateElement("a"))
+	fragment.AppendChild(doc.CreateElement("b"))
+
+	observer := NewMutationObserver(nil)
+	defer observer.Disconnect()
+	observer.Observe(div, MutationObserverInit{ChildList: true})
+	observer.Observe(fragment, MutationObserverInit{ChildList: true})
+
+	div.AppendChild(fragment)
+
+	records := observer.TakeRecords()
+	if len(records) != 2 {
+		t.Fatalf("Inserting a fragment must queue 2 records, got %v.", len(records))
+	}
+
+	if !records[0].Target().IsSameNode(fragment) || records[0].RemovedNodes().Length() != 2 {
+		t.Log("The fragment must queue a single record with its removed children.")
+		t.Fail()
+	}
+
+	if !records[1].Target().IsSameNode(div) || records[1].AddedNodes().Length() != 2 {
+		t.Log("The parent must queue a single record with the fragment children.")
+		t.Fail()
+	}
+}
+
+func TestMutationObserverReplaceAll(t *testing.T) {
+	doc := parseTestDocument(t, `<div><a></a><b></b></div>`)
+	div := doc.DocumentElement()
+
+	observer := NewMutationObserver(nil)
+	defer observer.Disconnect()
+	observer.Observe(div, MutationObserverInit{ChildList: true})
+
+	replacements := []struct {
+		name    string
+		replace func()
+		added   int
+	}{
+		{"SetTextContent", func() { div.SetTextContent("text") }, 1},
+		{"SetInnerGOML", func() { div.SetInnerGOML("<i></i><u></u>text") }, 3},
+		{"ReplaceChildren", func() { div.ReplaceChildren("a", doc.CreateElement("p")) }, 2},
+		{"SetTextContent", func() { div.SetTextContent("") }, 0},
+	}
+
+	removed := 2
+	for _, replacement := range replacements {
+		replacement.replace()
+
+		records := observer.TakeRecords()
+		if len(records) != 1 {
+			t.Logf("%v must queue a single record, got %v.", replacement.name, len(records))
+			t.Fail()
+			continue
+		}
+
+		if records[0].AddedNodes().Length() != replacement.added || records[0].RemovedNodes().Length() != removed {
+			t.Logf("%v must queue a record with the %v added and %v removed nodes, got %v and %v.",
+				replacement.name, replacement.added, removed,
+				records[0].AddedNodes().Length(), records[0].RemovedNodes().Length())
+			t.Fail()
+		}
+
+		if records[0].PreviousSibling() != nil || records[0].NextSibling() != nil {
+			t.Logf("%v must queue a record without siblings.", replacement.name)
+			t.Fail()
+		}
+
+		removed = replacement.added
+	}
+}
+
+func TestMutationObserverAttributes(t *testing.T) {
+	doc := parseTestDocument(t, `<div class="a"><p></p></div>`)
+	div := doc.DocumentElement()
+	p := div.FirstElementChild()
+
+	observer := NewMutationObserver(nil)
+	defer observer.Disconnect()
+	observer.Observe(div, MutationObserverInit{
+		Subtree:           true,
+		AttributeOldValue: true,
+		AttributeFilter:   []string{"class", "id"},
+	})
+
+	div.SetAttribute("class", "b")
+	div.SetAttribute("title", "ignored")
+	p.SetAttribute("id", "p")
+	div.RemoveAttribute("class")
+
+	records := observer.TakeRecords()
+	if types := recordTypes(records); !equalStrings(types, []string{"attributes", "attributes", "attributes"}) {
+		t.Fatalf("The filtered attributes must queue records, got %v.", types)
+	}
+
+	if records[0].AttributeName() != "class" || records[0].OldValue() == nil || *records[0].OldValue() != "a" {
+		t.Log("Changing an attribute must queue its old value.")
+		t.Fail()
+	}
+
+	if !records[1].Target().IsSameNode(p) || records[1].OldValue() != nil {
+		t.Log("Adding an attribute to a descendant must queue a record without old value.")
+		t.Fail()
+	}
+
+	if records[2].OldValue() == nil || *records[2].OldValue() != "b" {
+		t.Log("Removing an attribute must queue its old value.")
+		t.Fail()
+	}
+}
+
+func TestMutationObserverCharacterData(t *testing.T) {
+	doc := parseTestDocument(t, `<div><p>Hello</p></div>`)
+	div := doc.DocumentElement()
+	text := div.FirstChild().FirstChild().(Text)
+
+	withOld := NewMutationObserver(nil)
+	defer withOld.Disconnect()
+	withOld.Observe(div, MutationObserverInit{Subtree: true, CharacterDataOldValue: true})
+
+	withoutOld := NewMutationObserver(nil)
+	defer withoutOld.Disconnect()
+	withoutOld.Observe(text, MutationObserverInit{CharacterData: true})
+
+	text.AppendData(" world")
+	text.ReplaceData(0, 5, "Bye")
+
+	records := withOld.TakeRecords()
+	if len(records) != 2 || *records[0].OldValue() != "Hello" || *records[1].OldValue() != "Hello world" {
+		t.Log("CharacterData edits must queue records with the old data.")
+		t.Fail()
+	}
+
+	records = withoutOld.TakeRecords()
+	if len(records) != 2 || records[0].OldValue() != nil {
+		t.Log("The old data must only be given to the observers requesting it.")
+		t.Fail()
+	}
+
+	doc.NotifyMutationObservers()
+}
+
+func TestMutationObserverTransient(t *testing.T) {
+	doc := parseTestDocument(t, `<div><p>Hello</p></div>`)
+	div := doc.DocumentElement()
+	p := div.FirstChild()
+
+	var records []MutationRecord
+	observer := NewMutationObserver(func(r []MutationRecord, _ MutationObserver) {
+		records = append(records, r...)
+	})
+	defer observer.Disconnect()
+	observer.Observe(div, MutationObserverInit{Subtree: true, CharacterData: true, ChildList: true})
+
+	div.RemoveChild(p)
+	// The removed subtree is still observed until the
+	// checkpoint.
+	p.FirstChild().(Text).SetData("Bye")
+
+	doc.NotifyMutationObservers()
+	if types := recordTypes(records); !equalStrings(types, []string{"childList", "characterData"}) {
+		t.Logf("The removed subtree must be observed until the checkpoint, got %v.", types)
+		t.Fail()
+	}
+
+	p.FirstChild().(Text).SetData("Hello")
+	doc.NotifyMutationObservers()
+	if len(records) != 2 {
+		t.Log("The removed subtree must not be observed after the checkpoint.")
+		t.Fail()
+	}
+}
+
+func TestMutationObserverObserveDisconnect(t *testing.T) {
+	doc := parseTestDocument(t, `<div></div>`)
+	div := doc.DocumentElement()
+
+	observer := NewMutationObserver(nil)
+	if err := observer.Observe(div, MutationObserverInit{Subtree: true}); err == nil || err.Name() != "TypeError" {
+		t.Logf("Observing without a mutation type must return a TypeError, got %v.", err)
+		t.Fail()
+	}
+
+	observer.Observe(div, MutationObserverInit{ChildList: true})
+	// Observing again replace the options
+	observer.Observe(div, MutationObserverInit{Attributes: true})
+
+	div.AppendChild(doc.CreateElement("p"))
+	div.SetAttribute("id", "div")
+	if types := recordTypes(observer.TakeRecords()); !equalStrings(types, []string{"attributes"}) {
+		t.Logf("Observing again must replace the options, got %v.", types)
+		t.Fail()
+	}
+
+	div.SetAttribute("id", "other")
+	observer.Disconnect()
+	div.SetAttribute("id", "disconnected")
+
+	if records := observer.TakeRecords(); len(records) != 0 {
+		t.Logf("Disconnect must discard the records and stop the observation, got %v.", len(records))
+		t.Fail()
+	}
+
+	if registrations := doc.mutationObserverQueue().registrations; registrations != 0 {
+		t.Logf("All the registrations must be removed, got %v.", registrations)
+		t.Fail()
+	}
+}
@@ -26,7 +26,15 @@ type NamedNodeMap interface {
 var _ NamedNodeMap = &namedNodeMap{}
 
 type namedNodeMap struct {
-	dict map[string]Attr
+	ownerElement Element
+	dict         map[string]Attr
+}
+
+func newNamedNodeMap(ownerElement Element) NamedNodeMap {
+	return &namedNodeMap{
+		ownerElement: ownerElement,
+		dict:         make(map[string]Attr),
+	}
 }
 
 func (n *namedNodeMap) getNamedItem(name string) (Attr, bool) {
@@ -58,14 +66,31 @@ func (n *namedNodeMap) GetNamedItem(name string) Attr {
 // the index is higher or equal to the number of nodes.
 // (Slower than GetNamedItem)
 func (n *namedNodeMap) Item(index int) Attr {
+	if index < 0 || index >= n.Length() {
+		return nil
+	}
+
 	return n.Values()[index]
 }
 
 // SetNamedItem Replaces, or adds, the Attr identified
 // in the map by the given name.
 func (n *namedNodeMap) SetNamedItem(attr Attr) {
+	var oldValue *string
+	if old := n.dict[attr.Name()]; old != nil {
+		value := old.Value()
+		oldValue = &value
+		old.setOwnerElement(nil)
+	}
+
 	// Set the new attribute value
 	n.dict[attr.Name()] = attr
+	attr.setOwnerElement(n.ownerElement)
+
+	if n.ownerElement != nil {
+		value := attr.Value()
+		n.ownerElement.attributeChanged(attr.Name(), oldValue, &value)
+	}
 }
 
 // RemoveNamedItem remove the specified attribute.
@@ -78,13 +103,19 @@ func (n *namedNodeMap) RemoveNamedItem(name string) (Attr, e.Exception) {
 	}
 
 	delete(n.dict, name)
+	attr.setOwnerElement(nil)
+
+	if n.ownerElement != nil {
+		oldValue := attr.Value()
+		n.ownerElement.attributeChanged(name, &oldValue, nil)
+	}
 
 	return attr, nil
 }
 
 // Values return an iterable array of attributes.
 func (n *namedNodeMap) Values() []Attr {
-	arr := make([]Attr, n.Length())
+	arr := make([]Attr, 0, n.Length())
 
 	for _, value := range n.dict {
 		arr = append(arr, value)
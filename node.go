@@ -1,6 +1,9 @@
 package gom
 
 import (
+	"reflect"
+	"strings"
+
 	e "github.com/negrel/gom/exception"
 )
 
@@ -25,8 +28,20 @@ import (
 type Node interface {
 	/* Private */
 	apply(func(self Node))
+	mutationObservers() []*registeredObserver
+	remove(child Node, suppressObservers bool)
+	replaceAll(node Node)
+	setMutationObservers(observers []*registeredObserver)
+	setOrder(root Node, version uint64, start, end int)
+	setNextSibling(sibling Node)
 	setParentElement(parent Element)
 	setParentNode(parent Node)
+	setPreviousSibling(sibling Node)
+	touch()
+	treeOrder() (start, end int)
+	version() uint64
+	/* EMBEDDED INTERFACE */
+	EventTarget
 	/* GETTERS & SETTERS (props) */
 	ChildNodes() NodeList
 	FirstChild() Node
@@ -42,13 +57,13 @@ type Node interface {
 	SetOwnerDocument(doc Document)
 	SetTextContent(content string)
 	/* METHODS */
-	AppendChild(child Node) Node
+	AppendChild(child Node) (Node, e.Exception)
 	CloneNode(deep bool) Node
 	CompareDocumentPosition(other Node) int
 	Contains(other Node) bool
 	GetRootNode() Node
 	HasChildNodes() bool
-	InsertBefore(new, reference Node) Node
+	InsertBefore(new, reference Node) (Node, e.Exception)
 	IsEqualNode(other Node) bool
 	IsSameNode(other Node) bool
 	Normalize()
@@ -59,11 +74,36 @@ type Node interface {
 var _ Node = &node{}
 
 type node struct {
-	childNodes    NodeList
-	isConnected   bool
-	parentNode    Node
-	parentElement Element
-	document      Document
+	// eventTarget is embedded by value to avoid an
+	// allocation per node.
+	eventTarget
+	// self is the Node embedding this node (Element,
+	// Text, Document...) or the node itself.
+	self        Node
+	childNodes  NodeList
+	isConnected bool
+	// The children are a linked list of siblings.
+	firstChild      Node
+	lastChild       Node
+	childCount      int
+	previousSibling Node
+	nextSibling     Node
+	parentNode      Node
+	parentElement   Element
+	document        Document
+	// treeVersion is incremented on each modification
+	// of the node subtree.
+	treeVersion uint64
+	// orderStart and orderEnd are the tree order keys of
+	// the node and its last descendant in orderRoot,
+	// valid as long as the orderRoot version is orderVersion.
+	orderStart   int
+	orderEnd     int
+	orderRoot    Node
+	orderVersion uint64
+	// observers are the MutationObserver registered on
+	// the node.
+	observers []*registeredObserver
 }
 
 // The CompareDocumentPosition return values
@@ -89,27 +129,250 @@ const (
 	CommentNode
 	DocumentNode
 	DocumentTypeNode
+	DocumentFragmentNode
 )
 
 func newNode() Node {
-	return &node{
-		childNodes:    newNodeList(),
+	n := embedNode(nil)
+	n.self = n
+	n.eventTarget.self = n
+
+	return n
+}
+
+// embedNode return a new node to be embedded by the
+// given self node.
+func embedNode(self Node) *node {
+	n := &node{
+		self:          self,
 		isConnected:   false,
 		parentNode:    nil,
 		parentElement: nil,
 		document:      nil,
 	}
+	n.childNodes = newChildNodeList(n)
+	n.eventTarget.self = self
+
+	return n
 }
 
 // apply the function to the node and all is descendant.
 func (n *node) apply(fn func(node Node)) {
 	// apply to the node itself
-	fn(n)
+	fn(n.self)
 
 	// apply to all the children
-	n.childNodes.ForEach(func(_ int, child Node) {
+	for child := n.firstChild; child != nil; child = child.NextSibling() {
 		child.apply(fn)
-	})
+	}
+}
+
+// descendants call fn for each descendant of root in
+// tree order until fn return false. It report whether
+// the traversal was completed.
+func descendants(root Node, fn func(Node) bool) bool {
+	for child := root.FirstChild(); child != nil; child = child.NextSibling() {
+		if !fn(child) || !descendants(child, fn) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// detach remove the node from its parent if any.
+func detach(n Node) {
+	if parent := n.ParentNode(); parent != nil {
+		parent.RemoveChild(n)
+	}
+}
+
+// insert the node, or the children of the DocumentFragment
+// node, in this node before the child or at the end if
+// the child is nil. The inserted nodes are removed from
+// their old parent. A childList record is queued unless
+// suppressObservers is true.
+// https://dom.spec.whatwg.org/#concept-node-insert
+func (n *node) insert(node, child Node, suppressObservers bool) {
+	nodes := []Node{node}
+
+	if node.NodeType() == DocumentFragmentNode {
+		// Values is a snapshot, the children are removed
+		// from the fragment while inserted.
+		nodes = node.ChildNodes().Values()
+
+		for _, c := range nodes {
+			node.remove(c, true)
+		}
+		queueTreeMutationRecord(node, nil, nodes, nil, nil)
+	}
+
+	if len(nodes) == 0 {
+		return
+	}
+
+	for _, c := range nodes {
+		// Node already exist in a tree
+		// So move it from its current position
+		detach(c)
+
+		for lr := range liveRangesOf(n.self) {
+			lr.childInserted(n.self, child)
+		}
+
+		n.link(c, child)
+		n.adopt(c)
+		n.childInserted(c)
+	}
+
+	if !suppressObservers {
+		queueTreeMutationRecord(n.self, nodes, nil, nodes[0].PreviousSibling(), child)
+	}
+}
+
+// remove the child from this node. A childList record
+// is queued unless suppressObservers is true.
+// https://dom.spec.whatwg.org/#concept-node-remove
+func (n *node) remove(child Node, suppressObservers bool) {
+	// Updating the iterators before the removal
+	if doc := child.OwnerDocument(); doc != nil {
+		for iterator := range doc.nodeIterators() {
+			iterator.preRemove(child)
+		}
+	}
+
+	for lr := range liveRangesOf(n.self) {
+		lr.childRemoving(n.self, child)
+	}
+
+	previousSibling, nextSibling := child.PreviousSibling(), child.NextSibling()
+	n.unlink(child)
+
+	// Removing parent of the child
+	child.setParentNode(nil)
+	child.setParentElement(nil)
+	n.childRemoved(child)
+
+	if !suppressObservers {
+		queueTreeMutationRecord(n.self, nil, []Node{child}, previousSibling, nextSibling)
+	}
+	addTransientObservers(n.self, child)
+}
+
+// replaceAll replace all the children of this node by
+// the given node, or remove them if node is nil. A single
+// childList record is queued for this node.
+// https://dom.spec.whatwg.org/#concept-node-replace-all
+func (n *node) replaceAll(node Node) {
+	removedNodes := n.childNodes.Values()
+
+	var addedNodes []Node
+	if node != nil {
+		if node.NodeType() == DocumentFragmentNode {
+			addedNodes = node.ChildNodes().Values()
+		} else {
+			addedNodes = []Node{node}
+		}
+	}
+
+	for _, child := range removedNodes {
+		n.remove(child, true)
+	}
+
+	if node != nil {
+		n.insert(node, nil, true)
+	}
+
+	if len(addedNodes) > 0 || len(removedNodes) > 0 {
+		queueTreeMutationRecord(n.self, addedNodes, removedNodes, nil, nil)
+	}
+}
+
+// connectedDocument return the document n is connected
+// to or nil if the root of n is not a document.
+func connectedDocument(n Node) Document {
+	doc, _ := n.GetRootNode().(Document)
+
+	return doc
+}
+
+// childInserted run the steps following the insertion
+// of the given child in this node.
+// https://dom.spec.whatwg.org/#concept-node-insert-ext
+func (n *node) childInserted(child Node) {
+	n.touch()
+
+	if doc := connectedDocument(n.self); doc != nil {
+		doc.elementIds().addSubtree(child)
+	}
+}
+
+// childRemoved run the steps following the removal of
+// the given child from this node.
+// https://dom.spec.whatwg.org/#concept-node-remove-ext
+func (n *node) childRemoved(child Node) {
+	n.touch()
+
+	if doc := connectedDocument(n.self); doc != nil {
+		doc.elementIds().removeSubtree(child)
+	}
+}
+
+// touch increment the version of the node and its
+// ancestors, invalidating the live collections.
+func (n *node) touch() {
+	n.treeVersion++
+
+	if n.parentNode != nil {
+		n.parentNode.touch()
+	}
+}
+
+// version return the version of the node subtree.
+func (n *node) version() uint64 {
+	return n.treeVersion
+}
+
+// treeOrder return the tree order key of the node and the
+// one of its last descendant. The keys of the whole tree
+// are computed again if the tree changed since the last
+// call, so comparing nodes of an unchanged tree is O(1).
+func (n *node) treeOrder() (start, end int) {
+	root := n.GetRootNode()
+
+	if n.orderRoot != root || n.orderVersion != root.version() {
+		numberTree(root)
+	}
+
+	return n.orderStart, n.orderEnd
+}
+
+func (n *node) setOrder(root Node, version uint64, start, end int) {
+	n.orderRoot = root
+	n.orderVersion = version
+	n.orderStart = start
+	n.orderEnd = end
+}
+
+// numberTree set the tree order keys of root and all its
+// descendants.
+func numberTree(root Node) {
+	version := root.version()
+	order := 0
+
+	var number func(n Node)
+	number = func(n Node) {
+		start := order
+		order++
+
+		for child := n.FirstChild(); child != nil; child = child.NextSibling() {
+			number(child)
+		}
+
+		n.setOrder(root, version, start, order-1)
+	}
+
+	number(root)
 }
 
 func (n *node) setParentElement(parent Element) {
@@ -120,6 +383,82 @@ func (n *node) setParentNode(parent Node) {
 	n.parentNode = parent
 }
 
+func (n *node) setNextSibling(sibling Node) {
+	n.nextSibling = sibling
+}
+
+func (n *node) setPreviousSibling(sibling Node) {
+	n.previousSibling = sibling
+}
+
+func (n *node) mutationObservers() []*registeredObserver {
+	return n.observers
+}
+
+func (n *node) setMutationObservers(observers []*registeredObserver) {
+	n.observers = observers
+}
+
+// link insert the child in the children of this node
+// before the reference or at the end if it is nil.
+func (n *node) link(child, reference Node) {
+	previous := n.lastChild
+	if reference != nil {
+		previous = reference.PreviousSibling()
+	}
+
+	child.setPreviousSibling(previous)
+	child.setNextSibling(reference)
+
+	if previous == nil {
+		n.firstChild = child
+	} else {
+		previous.setNextSibling(child)
+	}
+
+	if reference == nil {
+		n.lastChild = child
+	} else {
+		reference.setPreviousSibling(child)
+	}
+
+	n.childCount++
+}
+
+// unlink remove the child from the children of
+// this node.
+func (n *node) unlink(child Node) {
+	previous, next := child.PreviousSibling(), child.NextSibling()
+
+	if previous == nil {
+		n.firstChild = next
+	} else {
+		previous.setNextSibling(next)
+	}
+
+	if next == nil {
+		n.lastChild = previous
+	} else {
+		next.setPreviousSibling(previous)
+	}
+
+	child.setPreviousSibling(nil)
+	child.setNextSibling(nil)
+	n.childCount--
+}
+
+// adopt set the parent pointers of the given child
+// to this node.
+func (n *node) adopt(child Node) {
+	child.setParentNode(n.self)
+
+	if parent, isElement := n.self.(Element); isElement {
+		child.setParentElement(parent)
+	} else {
+		child.setParentElement(nil)
+	}
+}
+
 /*****************************************************
  **************** Getters & Setters ******************
  *****************************************************/
@@ -133,23 +472,19 @@ func (n *node) ChildNodes() NodeList {
 // FirstChild method return the first child of the
 // current node.
 func (n *node) FirstChild() Node {
-	return n.childNodes.Item(0)
+	return n.firstChild
 }
 
 // LastChild method return the last child of the
 // current node.
 func (n *node) LastChild() Node {
-	lastIndex := n.childNodes.Length() - 1
-
-	return n.childNodes.Item(lastIndex)
+	return n.lastChild
 }
 
 // NextSibling - method return the next sibling
 // of the current node.
 func (n *node) NextSibling() Node {
-	index := n.parentNode.ChildNodes().IndexOf(n)
-
-	return n.parentNode.ChildNodes().Item(index + 1)
+	return n.nextSibling
 }
 
 // NodeName method return a DOMString containing
@@ -188,17 +523,25 @@ func (n *node) ParentElement() Element {
 // PreviousSibling method return the previous
 // sibling of the current node.
 func (n *node) PreviousSibling() Node {
-	index := n.parentNode.ChildNodes().IndexOf(n)
-
-	return n.parentNode.ChildNodes().Item(index - 1)
+	return n.previousSibling
 }
 
 // TextContent methode return the textual content
 // of an element and all its descendants.
+// https://developer.mozilla.org/en-US/docs/Web/API/Node/textContent
+// https://dom.spec.whatwg.org/#dom-node-textcontent
 func (n *node) TextContent() string {
-	// TODO func (n *node) TextContent() string
-	// https://dom.spec.whatwg.org/#dom-node-textcontent
-	return ""
+	var b strings.Builder
+
+	descendants(n.self, func(descendant Node) bool {
+		if text, isText := descendant.(Text); isText && text.NodeType() == TextNode {
+			b.WriteString(text.Data())
+		}
+
+		return true
+	})
+
+	return b.String()
 }
 
 func (n *node) SetOwnerDocument(doc Document) {
@@ -206,10 +549,25 @@ func (n *node) SetOwnerDocument(doc Document) {
 }
 
 // SetTextContent methode set the textual content
-// of an element and all its descendants.
+// of an element and all its descendants. All the
+// children are replaced by a single Text node or
+// none if the content is empty.
+// https://developer.mozilla.org/en-US/docs/Web/API/Node/textContent
+// https://dom.spec.whatwg.org/#string-replace-all
 func (n *node) SetTextContent(content string) {
-	// TODO func (n *node) SetTextContent(string)
-	// https://dom.spec.whatwg.org/#dom-node-textcontent
+	if content == "" {
+		n.replaceAll(nil)
+		return
+	}
+
+	var text Text
+	if doc := nodeDocument(n.self); doc != nil {
+		text = doc.CreateTextNode(content)
+	} else {
+		text = createTextNode(content)
+	}
+
+	n.replaceAll(text)
 }
 
 /*****************************************************
@@ -219,19 +577,17 @@ func (n *node) SetTextContent(content string) {
 
 // AppendChild methods adds the specified childNode
 // argument as the last child to the current node.
+// A HierarchyRequestError exception is returned if the
+// child can't be appended to this node.
 // https://developer.mozilla.org/en-US/docs/Web/API/Node/appendChild
-func (n *node) AppendChild(child Node) Node {
-	// Child already exist in the tree
-	// So move it from its current position
-	if n.GetRootNode().Contains(child) {
-		// TODO
+func (n *node) AppendChild(child Node) (Node, e.Exception) {
+	if err := ensurePreInsertionValidity(n.self, child, nil); err != nil {
+		return nil, err
 	}
 
-	// Appending the child.
-	child = n.childNodes.append(child)
-	child.setParentNode(n)
+	n.insert(child, nil, false)
 
-	return child
+	return child, nil
 }
 
 // CloneNode method return a duplicate of the node on
@@ -243,7 +599,7 @@ func (n *node) CloneNode(deep bool) Node {
 
 	// Copy node children
 	if deep {
-		for _, child := range n.childNodes.Values() {
+		for child := n.firstChild; child != nil; child = child.NextSibling() {
 			clone.AppendChild(child.CloneNode(true))
 		}
 	}
@@ -254,35 +610,86 @@ func (n *node) CloneNode(deep bool) Node {
 // CompareDocumentPosition method compares the position
 // of the given node against another node in any document.
 // https://developer.mozilla.org/en-US/docs/Web/API/Node/compareDocumentPosition
+// https://dom.spec.whatwg.org/#dom-node-comparedocumentposition
 func (n *node) CompareDocumentPosition(other Node) int {
-	// TODO func (n *node) CompareDocumentPosition(other Node) int
-	// https://dom.spec.whatwg.org/#dom-node-comparedocumentposition
-	return 0
+	if other == nil {
+		return DocumentPositionDisconnected | DocumentPositionImplementationSpecific
+	}
+
+	if n.self.IsSameNode(other) {
+		return 0
+	}
+
+	node1, node2 := other, n.self
+	var attr1, attr2 Attr
+
+	// Attributes are compared through their owner element
+	if attr, isAttr := node1.(Attr); isAttr {
+		attr1 = attr
+		node1 = attr.OwnerElement()
+	}
+	if attr, isAttr := node2.(Attr); isAttr {
+		attr2 = attr
+		node2 = attr.OwnerElement()
+
+		if attr1 != nil && node1 != nil && node1.IsSameNode(node2) {
+			for _, a := range attr.OwnerElement().Attributes().Values() {
+				if a.IsSameNode(attr1) {
+					return DocumentPositionImplementationSpecific | DocumentPositionPreceding
+				}
+				if a.IsSameNode(attr2) {
+					return DocumentPositionImplementationSpecific | DocumentPositionFollowing
+				}
+			}
+		}
+	}
+
+	if node1 == nil || node2 == nil || !node1.GetRootNode().IsSameNode(node2.GetRootNode()) {
+		// Consistent ordering of the disconnected trees
+		position := DocumentPositionFollowing
+		if reflect.ValueOf(other).Pointer() < reflect.ValueOf(n.self).Pointer() {
+			position = DocumentPositionPreceding
+		}
+
+		return DocumentPositionDisconnected | DocumentPositionImplementationSpecific | position
+	}
+
+	start1, end1 := node1.treeOrder()
+	start2, end2 := node2.treeOrder()
+	sameNode := start1 == start2
+
+	switch {
+	// node1 is an ancestor of node2
+	case (start1 < start2 && start2 <= end1 && attr1 == nil) || (sameNode && attr2 != nil):
+		return DocumentPositionContains | DocumentPositionPreceding
+
+	// node1 is a descendant of node2
+	case (start2 < start1 && start1 <= end2 && attr2 == nil) || (sameNode && attr1 != nil):
+		return DocumentPositionContainedBy | DocumentPositionFollowing
+
+	case start1 < start2:
+		return DocumentPositionPreceding
+	}
+
+	return DocumentPositionFollowing
 }
 
 // Contains method returns a Boolean value indicating
 // whether a node is a descendant of a given node.
 // https://developer.mozilla.org/en-US/docs/Web/API/Node/contains
-func (n *node) Contains(other Node) (contain bool) {
-	// Checking first gen child
-	for _, childNode := range n.childNodes.Values() {
-		if childNode.IsSameNode(other) {
-			contain = true
-			break
-		}
+func (n *node) Contains(other Node) bool {
+	if other == nil {
+		return false
 	}
 
-	// Checking child of child and so on
-	if !contain {
-		for _, childNode := range n.childNodes.Values() {
-			if childNode.Contains(other) {
-				contain = true
-				break
-			}
+	// Looking for this node in the ancestors of other
+	for ancestor := other.ParentNode(); ancestor != nil; ancestor = ancestor.ParentNode() {
+		if ancestor.IsSameNode(n.self) {
+			return true
 		}
 	}
 
-	return contain
+	return false
 }
 
 // GetRootNode method of the node interface returns
@@ -295,54 +702,47 @@ func (n *node) GetRootNode() Node {
 	}
 
 	// No parent, this node is the root node.
-	return n
+	return n.self
 }
 
 // HasChildNodes method returns a bool value indicating
 // whether the given node has child nodes or not.
 // https://developer.mozilla.org/en-US/docs/Web/API/Node/hasChildNodes
 func (n *node) HasChildNodes() bool {
-	return n.childNodes.Length() > 0
+	return n.firstChild != nil
 }
 
 // InsertBefore method inserts a node before a reference
 // node as a child (or append the node if the reference
-// node is not a direct child) of the node on which this
-// method was called.
-// Return the inserted node.
+// node is nil) of the node on which this method was called.
+// Return the inserted node. A NotFoundError exception is
+// returned if the reference node is not a child of this node
+// and a HierarchyRequestError exception if the node can't be
+// inserted.
 // https://developer.mozilla.org/en-US/docs/Web/API/Node/insertBefore
-func (n *node) InsertBefore(new Node, reference Node) Node {
-	// Child already exist in the tree
-	// So move it from its current position
-	if n.GetRootNode().Contains(new) {
-		// TODO InsertBefore child already exist
+func (n *node) InsertBefore(new Node, reference Node) (Node, e.Exception) {
+	// No reference so we append the new node
+	if reference == nil {
+		return n.AppendChild(new)
 	}
 
-	// Reference node index
-	index := n.childNodes.IndexOf(reference)
-
-	// Reference is not found so we append the new node
-	if index == -1 {
-		return n.AppendChild(new)
+	if err := ensurePreInsertionValidity(n.self, new, reference); err != nil {
+		return nil, err
 	}
 
-	// Reference node is found let's insert the new node
-	// slice of child before the new node
-	beforeNew := make([]Node, 0)
-	// slice of child after the new node
-	afterNew := make([]Node, 0)
+	// Inserting a node before itself is inserting it
+	// before its next sibling
+	if reference.IsSameNode(new) {
+		reference = new.NextSibling()
 
-	if index > 0 {
-		beforeNew = n.childNodes.Values()[:index-1]
-		afterNew = n.childNodes.Values()[index:]
+		if reference == nil {
+			return n.AppendChild(new)
+		}
 	}
 
-	// beforeNew + new + afterNew
-	n.childNodes.appendList(beforeNew...)
-	n.childNodes.append(new)
-	n.childNodes.appendList(afterNew...)
+	n.insert(new, reference, false)
 
-	return n.childNodes.Item(index)
+	return new, nil
 }
 
 // IsEqualNode method return whether two nodes are equal.
@@ -363,11 +763,9 @@ func (n *node) IsEqualNode(other Node) bool {
 	}
 
 	// Check children
-	for i, child := range n.childNodes.Values() {
-		if otherChild := other.ChildNodes().Item(i); otherChild != nil {
-			if !otherChild.IsEqualNode(child) {
-				goto notEqual
-			}
+	for child, otherChild := n.firstChild, other.FirstChild(); child != nil; child, otherChild = child.NextSibling(), otherChild.NextSibling() {
+		if !otherChild.IsEqualNode(child) {
+			goto notEqual
 		}
 	}
 
@@ -381,52 +779,66 @@ notEqual:
 // nodes are the same (reference).
 // https://developer.mozilla.org/en-US/docs/Web/API/Node/isSameNode
 func (n *node) IsSameNode(other Node) bool {
-	return n == other
+	return n.self == other
 }
 
 // Normalize method clean up all the text nodes under
 // this element (merge adjacent, remove empty).
 // https://developer.mozilla.org/en-US/docs/Web/API/Node/normalize
 func (n *node) Normalize() {
-	// TODO func (n *node) Normalize()
 	// https://dom.spec.whatwg.org/#dom-node-normalize
+	for child := n.FirstChild(); child != nil; {
+		next := child.NextSibling()
+
+		if child.NodeType() != TextNode {
+			child.Normalize()
+			child = next
+			continue
+		}
+
+		// Removing empty text
+		text := child.(Text)
+		if text.Length() == 0 {
+			n.self.RemoveChild(text)
+			child = next
+			continue
+		}
+
+		// Merging the contiguous text nodes data
+		var contiguous []Node
+		var data strings.Builder
+		for ; next != nil && next.NodeType() == TextNode; next = next.NextSibling() {
+			contiguous = append(contiguous, next)
+			data.WriteString(next.(Text).Data())
+		}
+		length := text.Length()
+		text.AppendData(data.String())
+
+		ranges := liveRangesOf(text)
+		for _, c := range contiguous {
+			for lr := range ranges {
+				lr.textMerged(text, c, length)
+			}
+			length += c.(Text).Length()
+
+			n.self.RemoveChild(c)
+		}
+
+		child = next
+	}
 }
 
 // RemoveChild method removes a child node from the DOM
 // and returns the removed node.
 // https://developer.mozilla.org/en-US/docs/Web/API/Node/removeChild
 func (n *node) RemoveChild(child Node) (Node, e.Exception) {
-	childIndex := n.childNodes.IndexOf(child)
-
 	// Child not found.
-	if err := childIndex == -1; err {
+	if child == nil || !isChildOf(child, n.self) {
 		return child,
 			e.New(e.NotFoundError, "The node to be removed is not a child of this node.")
 	}
 
-	// Slice of all the child before the child to remove
-	beforeChild := make([]Node, 0)
-	// Slice of all the child after the child to remove
-	afterChild := make([]Node, 0)
-
-	// If child have previousSibling there is child
-	// before the child to remove
-	if childIndex > 0 {
-		beforeChild = n.childNodes.Values()[:childIndex-1]
-	}
-	// If child have nextSibling there is child
-	// after the child to remove
-	if n.childNodes.Length() > (childIndex + 1) {
-		afterChild = n.childNodes.Values()[childIndex+1:]
-	}
-
-	// Appending slice of nodes child before and after
-	// the child to remove.
-	n.childNodes.appendList(beforeChild...)
-	n.childNodes.appendList(afterChild...)
-
-	// Removing parent of the child
-	child.setParentNode(nil)
+	n.remove(child, false)
 
 	return child, nil
 }
@@ -435,14 +847,171 @@ func (n *node) RemoveChild(child Node) (Node, e.Exception) {
 // given (parent) node.
 // https://developer.mozilla.org/en-US/docs/Web/API/Node/replaceChild
 func (n *node) ReplaceChild(newChild, oldChild Node) e.Exception {
+	if err := ensureReplacementValidity(n.self, newChild, oldChild); err != nil {
+		return err
+	}
+
+	// Nothing to replace
+	if newChild.IsSameNode(oldChild) {
+		return nil
+	}
+
+	reference := oldChild.NextSibling()
+	if reference != nil && reference.IsSameNode(newChild) {
+		reference = newChild.NextSibling()
+	}
+	previousSibling := oldChild.PreviousSibling()
+
+	nodes := []Node{newChild}
+	if newChild.NodeType() == DocumentFragmentNode {
+		nodes = newChild.ChildNodes().Values()
+	}
+
+	n.remove(oldChild, true)
+	n.insert(newChild, reference, true)
+	queueTreeMutationRecord(n.self, nodes, []Node{oldChild}, previousSibling, reference)
+
+	return nil
+}
+
+/*****************************************************
+ ******************** Validity ***********************
+ *****************************************************/
+// ANCHOR Validity
+
+// ensurePreInsertionValidity return an exception if node
+// can't be inserted into parent before child.
+// https://dom.spec.whatwg.org/#concept-node-ensure-pre-insertion-validity
+func ensurePreInsertionValidity(parent, node, child Node) e.Exception {
+	if child != nil && !isChildOf(child, parent) {
+		return e.New(e.NotFoundError, "The node before which the new node is to be inserted is not a child of this node.")
+	}
 
-	index := n.childNodes.IndexOf(oldChild)
+	return ensureValidity(parent, node, child, false)
+}
 
-	if index == -1 {
+// ensureReplacementValidity return an exception if child
+// of parent can't be replaced by node.
+// https://dom.spec.whatwg.org/#concept-node-replace
+func ensureReplacementValidity(parent, node, child Node) e.Exception {
+	if child == nil || !isChildOf(child, parent) {
 		return e.New(e.NotFoundError, "The node to be replaced is not a child of this node.")
 	}
 
-	n.childNodes.set(index, newChild)
+	return ensureValidity(parent, node, child, true)
+}
+
+// isChildOf report whether child is a child of parent.
+func isChildOf(child, parent Node) bool {
+	childParent := child.ParentNode()
+
+	return childParent != nil && childParent.IsSameNode(parent)
+}
+
+// ensureValidity contains the checks shared by the insertion
+// and the replacement of child by node in parent. The abstract
+// node (zero NodeType) can be both parent and child.
+func ensureValidity(parent, node, child Node, replace bool) e.Exception {
+	if node == nil {
+		return e.TypeError("The node to be inserted is nil.")
+	}
+
+	switch parent.NodeType() {
+	case DocumentNode, DocumentFragmentNode, ElementNode, 0:
+	default:
+		return hierarchyRequestError(node, parent)
+	}
+
+	// The node must not be an inclusive ancestor of parent
+	for ancestor := parent; ancestor != nil; ancestor = ancestor.ParentNode() {
+		if node.IsSameNode(ancestor) {
+			return e.New(e.HierarchyRequestError, "The new child element contains the parent.")
+		}
+	}
+
+	switch node.NodeType() {
+	case DocumentFragmentNode, ElementNode, TextNode, CommentNode, 0:
+		if node.NodeType() == TextNode && parent.NodeType() == DocumentNode {
+			return hierarchyRequestError(node, parent)
+		}
+
+	case DocumentTypeNode:
+		if parent.NodeType() != DocumentNode {
+			return hierarchyRequestError(node, parent)
+		}
+
+	default:
+		return hierarchyRequestError(node, parent)
+	}
+
+	if parent.NodeType() == DocumentNode {
+		return ensureDocumentValidity(parent, node, child, replace)
+	}
 
 	return nil
 }
+
+// ensureDocumentValidity check that the document parent will
+// have at most one doctype followed by at most one element.
+func ensureDocumentValidity(parent, node, child Node, replace bool) e.Exception {
+	children := parent.ChildNodes().Values()
+	childIndex := len(children)
+	if child != nil {
+		childIndex = parent.ChildNodes().IndexOf(child)
+	}
+
+	// has report whether parent has a child of the given
+	// type in the given range, ignoring the replaced child.
+	has := func(nodeType NodeType, from, to int) bool {
+		for _, c := range children[from:to] {
+			if c.NodeType() == nodeType && !(replace && c.IsSameNode(child)) {
+				return true
+			}
+		}
+
+		return false
+	}
+	hasChild := func(nodeType NodeType) bool {
+		return has(nodeType, 0, len(children))
+	}
+	doctypeFollowing := child != nil && has(DocumentTypeNode, childIndex, len(children))
+	elementPreceding := child != nil && has(ElementNode, 0, childIndex)
+	childIsDoctype := !replace && child != nil && child.NodeType() == DocumentTypeNode
+
+	switch node.NodeType() {
+	case DocumentFragmentNode:
+		elements := 0
+		for _, c := range node.ChildNodes().Values() {
+			switch c.NodeType() {
+			case ElementNode:
+				elements++
+			case TextNode:
+				return hierarchyRequestError(c, parent)
+			}
+		}
+
+		if elements > 1 ||
+			(elements == 1 && (hasChild(ElementNode) || childIsDoctype || doctypeFollowing)) {
+			return e.New(e.HierarchyRequestError, "Only one element on document allowed.")
+		}
+
+	case ElementNode:
+		if hasChild(ElementNode) || childIsDoctype || doctypeFollowing {
+			return e.New(e.HierarchyRequestError, "Only one element on document allowed.")
+		}
+
+	case DocumentTypeNode:
+		if hasChild(DocumentTypeNode) || elementPreceding || (child == nil && hasChild(ElementNode)) {
+			return e.New(e.HierarchyRequestError, "Only one doctype, preceding the document element, on document allowed.")
+		}
+	}
+
+	return nil
+}
+
+// hierarchyRequestError return the HierarchyRequestError
+// exception for node that can't be inserted in parent.
+func hierarchyRequestError(node, parent Node) e.Exception {
+	return e.New(e.HierarchyRequestError,
+		"Nodes of type '%v' may not be inserted inside nodes of type '%v'.", node.NodeName(), parent.NodeName())
+}
@@ -0,0 +1,74 @@
+package gom
+
+// NodeFilter is called by the TreeWalker and NodeIterator
+// objects to filter the nodes. It returns FilterAccept,
+// FilterReject or FilterSkip.
+// https://developer.mozilla.org/en-US/docs/Web/API/NodeFilter
+// https://dom.spec.whatwg.org/#interface-nodefilter
+type NodeFilter func(node Node) int
+
+// The NodeFilter return values.
+const (
+	// FilterAccept accept the node.
+	FilterAccept = iota + 1
+	// FilterReject reject the node, the TreeWalker also
+	// reject its descendants.
+	FilterReject
+	// FilterSkip skip the node but not its descendants.
+	FilterSkip
+)
+
+// The whatToShow bitmask values, one bit per NodeType.
+const (
+	ShowElement uint32 = 1 << iota
+	ShowAttribute
+	ShowText
+	ShowComment
+	ShowDocument
+	ShowDocumentType
+	ShowDocumentFragment
+	ShowAll uint32 = 0xFFFFFFFF
+)
+
+// traversal contains the properties shared by the
+// TreeWalker and NodeIterator objects.
+type traversal struct {
+	root       Node
+	whatToShow uint32
+	filter     NodeFilter
+}
+
+// filterNode return FilterSkip if the node type is not
+// shown or the filter result.
+// https://dom.spec.whatwg.org/#concept-node-filter
+func (t *traversal) filterNode(node Node) int {
+	if t.whatToShow&(1<<(node.NodeType()-1)) == 0 {
+		return FilterSkip
+	}
+
+	if t.filter == nil {
+		return FilterAccept
+	}
+
+	return t.filter(node)
+}
+
+/*****************************************************
+ **************** Getters & Setters ******************
+ *****************************************************/
+// ANCHOR Getters & Setters
+
+// Filter return the NodeFilter used to select the nodes.
+func (t *traversal) Filter() NodeFilter {
+	return t.filter
+}
+
+// Root return the root node of the traversal.
+func (t *traversal) Root() Node {
+	return t.root
+}
+
+// WhatToShow return the bitmask of the node types to show.
+func (t *traversal) WhatToShow() uint32 {
+	return t.whatToShow
+}
@@ -0,0 +1,202 @@
+package gom
+
+// NodeIterator object represents an iterator over the
+// nodes of a document subtree in tree order. The
+// iterator stays valid when nodes are removed until it is
+// released: the document keeps a reference to each
+// iterator until its Release method is called.
+// https://developer.mozilla.org/en-US/docs/Web/API/NodeIterator
+// https://dom.spec.whatwg.org/#interface-nodeiterator
+type NodeIterator interface {
+	/* GETTERS & SETTERS (props) */
+	Filter() NodeFilter
+	PointerBeforeReferenceNode() bool
+	ReferenceNode() Node
+	Root() Node
+	WhatToShow() uint32
+	/* METHODS */
+	Detach()
+	NextNode() Node
+	PreviousNode() Node
+	Release()
+}
+
+var _ NodeIterator = &nodeIterator{}
+
+type nodeIterator struct {
+	*traversal
+	document                   Document
+	reference                  Node
+	pointerBeforeReferenceNode bool
+}
+
+// newNodeIterator return a new NodeIterator registered in
+// the given document to be updated when nodes are removed.
+func newNodeIterator(doc Document, root Node, whatToShow uint32, filter NodeFilter) NodeIterator {
+	ni := &nodeIterator{
+		traversal: &traversal{
+			root:       root,
+			whatToShow: whatToShow,
+			filter:     filter,
+		},
+		document:                   doc,
+		reference:                  root,
+		pointerBeforeReferenceNode: true,
+	}
+	doc.nodeIterators()[ni] = struct{}{}
+
+	return ni
+}
+
+// followingNode return the node following node in tree
+// order within root, its descendants are skipped if
+// skipChildren is true.
+func followingNode(node, root Node, skipChildren bool) Node {
+	if !skipChildren && node.HasChildNodes() {
+		return node.FirstChild()
+	}
+
+	for ; node != nil && node != root; node = node.ParentNode() {
+		if sibling := node.NextSibling(); sibling != nil {
+			return sibling
+		}
+	}
+
+	return nil
+}
+
+// precedingNode return the node preceding node in tree
+// order within root.
+func precedingNode(node, root Node) Node {
+	if node == root {
+		return nil
+	}
+
+	if sibling := node.PreviousSibling(); sibling != nil {
+		return lastInclusiveDescendant(sibling)
+	}
+
+	return node.ParentNode()
+}
+
+// lastInclusiveDescendant return the last inclusive
+// descendant of node in tree order.
+func lastInclusiveDescendant(node Node) Node {
+	for node.HasChildNodes() {
+		node = node.LastChild()
+	}
+
+	return node
+}
+
+// traverse move the reference node to the next or
+// previous accepted node and return it.
+// https://dom.spec.whatwg.org/#concept-nodeiterator-traverse
+func (ni *nodeIterator) traverse(next bool) Node {
+	node := ni.reference
+	beforeNode := ni.pointerBeforeReferenceNode
+
+	for {
+		if next {
+			if !beforeNode {
+				if node = followingNode(node, ni.root, false); node == nil {
+					return nil
+				}
+			}
+			beforeNode = false
+		} else {
+			if beforeNode {
+				if node = precedingNode(node, ni.root); node == nil {
+					return nil
+				}
+			}
+			beforeNode = true
+		}
+
+		if ni.filterNode(node) == FilterAccept {
+			break
+		}
+	}
+
+	ni.reference = node
+	ni.pointerBeforeReferenceNode = beforeNode
+
+	return node
+}
+
+// preRemove update the reference node before the removal
+// of the given node.
+// https://dom.spec.whatwg.org/#nodeiterator-pre-removing-steps
+func (ni *nodeIterator) preRemove(toBeRemoved Node) {
+	if toBeRemoved == ni.root ||
+		(toBeRemoved != ni.reference && !toBeRemoved.Contains(ni.reference)) {
+		return
+	}
+
+	if ni.pointerBeforeReferenceNode {
+		// First following node not removed
+		if next := followingNode(toBeRemoved, ni.root, true); next != nil {
+			ni.reference = next
+			return
+		}
+
+		ni.pointerBeforeReferenceNode = false
+	}
+
+	if sibling := toBeRemoved.PreviousSibling(); sibling != nil {
+		ni.reference = lastInclusiveDescendant(sibling)
+	} else {
+		ni.reference = toBeRemoved.ParentNode()
+	}
+}
+
+/*****************************************************
+ **************** Getters & Setters ******************
+ *****************************************************/
+// ANCHOR Getters & Setters
+
+// PointerBeforeReferenceNode report whether the iterator
+// is positioned before the reference node.
+// https://developer.mozilla.org/en-US/docs/Web/API/NodeIterator/pointerBeforeReferenceNode
+func (ni *nodeIterator) PointerBeforeReferenceNode() bool {
+	return ni.pointerBeforeReferenceNode
+}
+
+// ReferenceNode return the node to which the iterator
+// is anchored.
+// https://developer.mozilla.org/en-US/docs/Web/API/NodeIterator/referenceNode
+func (ni *nodeIterator) ReferenceNode() Node {
+	return ni.reference
+}
+
+/*****************************************************
+ ********************* Methods ***********************
+ *****************************************************/
+// ANCHOR Methods
+
+// Detach does nothing, as specified. Use Release to stop
+// updating the iterator.
+// https://developer.mozilla.org/en-US/docs/Web/API/NodeIterator/detach
+// https://dom.spec.whatwg.org/#dom-nodeiterator-detach
+func (ni *nodeIterator) Detach() {}
+
+// NextNode returns the next node in the document or nil
+// if there is none.
+// https://developer.mozilla.org/en-US/docs/Web/API/NodeIterator/nextNode
+func (ni *nodeIterator) NextNode() Node {
+	return ni.traverse(true)
+}
+
+// PreviousNode returns the previous node in the document
+// or nil if there is none.
+// https://developer.mozilla.org/en-US/docs/Web/API/NodeIterator/previousNode
+func (ni *nodeIterator) PreviousNode() Node {
+	return ni.traverse(false)
+}
+
+// Release unregister the iterator from its document. The
+// iterator is no longer updated when nodes are removed
+// and the document no longer keeps a reference to it.
+func (ni *nodeIterator) Release() {
+	delete(ni.document.nodeIterators(), ni)
+}
@@ -0,0 +1,106 @@
+package gom
+
+import (
+	"testing"
+)
+
+func TestNodeIterator(t *testing.T) {
+	doc := parseTestDocument(t, traversalTestSrc)
+	iterator := doc.CreateNodeIterator(doc.DocumentElement(), ShowElement, func(node Node) int {
+		if node.(Element).ClassName() == "skip" {
+			return FilterReject
+		}
+
+		return FilterAccept
+	})
+
+	var forward []Node
+	for node := iterator.NextNode(); node != nil; node = iterator.NextNode() {
+		forward = append(forward, node)
+	}
+
+	// Rejected nodes descendants are iterated
+	expected := []string{"div", "p", "b", "ul", "li", "i", "span"}
+	if names := nodeNames(forward...); !equalStrings(names, expected) {
+		t.Logf("NextNode must iterate the nodes in tree order, got %v.", names)
+		t.Fail()
+	}
+
+	if iterator.PointerBeforeReferenceNode() || iterator.ReferenceNode().(Element).TagName() != "span" {
+		t.Log("Iterator must be after the last node.")
+		t.Fail()
+	}
+
+	var backward []Node
+	for node := iterator.PreviousNode(); node != nil; node = iterator.PreviousNode() {
+		backward = append(backward, node)
+	}
+
+	expected = []string{"span", "i", "li", "ul", "b", "p", "div"}
+	if names := nodeNames(backward...); !equalStrings(names, expected) {
+		t.Logf("PreviousNode must iterate the nodes in reverse tree order, got %v.", names)
+		t.Fail()
+	}
+}
+
+func TestNodeIteratorRemoval(t *testing.T) {
+	doc := parseTestDocument(t, traversalTestSrc)
+	iterator := doc.CreateNodeIterator(doc.DocumentElement(), ShowElement, nil)
+
+	// Iterator after ul
+	for node := iterator.NextNode(); node.(Element).TagName() != "ul"; node = iterator.NextNode() {
+	}
+
+	ul := iterator.ReferenceNode()
+	ul.ParentNode().RemoveChild(ul)
+
+	// Last node preceding the removed node is the "b" text
+	if reference := iterator.ReferenceNode(); reference.NodeType() != TextNode || iterator.PointerBeforeReferenceNode() {
+		t.Logf("Reference must move to the preceding node, got %v.", nodeNames(reference))
+		t.Fail()
+	}
+
+	if next := iterator.NextNode(); next == nil || next.(Element).TagName() != "span" {
+		t.Logf("Iteration must continue after the removed node, got %v.", nodeNames(next))
+		t.Fail()
+	}
+
+	// Pointer before the reference node
+	if previous := iterator.PreviousNode(); previous == nil || previous.(Element).TagName() != "span" {
+		t.Fatalf("PreviousNode must return the reference node, got %v.", nodeNames(previous))
+	}
+
+	span := iterator.ReferenceNode()
+	span.ParentNode().RemoveChild(span)
+
+	// No following node, the reference moves backward
+	if reference := iterator.ReferenceNode(); reference.NodeType() != TextNode || iterator.PointerBeforeReferenceNode() {
+		t.Logf("Reference must move to the preceding node, got %v.", nodeNames(reference))
+		t.Fail()
+	}
+
+	// Detach does nothing
+	iterator.Detach()
+	text := iterator.ReferenceNode()
+	text.ParentNode().RemoveChild(text)
+
+	if iterator.ReferenceNode().IsSameNode(text) {
+		t.Log("Detached iterator must still be updated.")
+		t.Fail()
+	}
+
+	// Released iterator
+	iterator.Release()
+	reference := iterator.ReferenceNode()
+	reference.ParentNode().RemoveChild(reference)
+
+	if !iterator.ReferenceNode().IsSameNode(reference) {
+		t.Log("Released iterator must not be updated.")
+		t.Fail()
+	}
+
+	if len(doc.nodeIterators()) != 0 {
+		t.Log("Released iterator must be removed from its document.")
+		t.Fail()
+	}
+}
@@ -1,12 +1,8 @@
 package gom
 
-// NodeList objects are collections of nodes (live)
+// NodeList objects are collections of nodes
 // https://developer.mozilla.org/en-US/docs/Web/API/NodeList
 type NodeList interface {
-	/* Private */
-	append(node Node) Node
-	appendList(nodes ...Node)
-	set(index int, node Node)
 	/* GETTERS & SETTERS */
 	Length() int
 	/* METHODS */
@@ -17,7 +13,9 @@ type NodeList interface {
 }
 
 var _ NodeList = &nodeList{}
+var _ NodeList = &childNodeList{}
 
+// nodeList is a static NodeList.
 type nodeList struct {
 	list []Node
 }
@@ -32,12 +30,22 @@ func (nl *nodeList) append(node Node) Node {
 	return child
 }
 
-func (nl *nodeList) appendList(nodes ...Node) {
-	nl.list = append(nl.list, nodes...)
+// childNodeList is a live NodeList of the children
+// of a node.
+type childNodeList struct {
+	parent *node
+	// cursor is the last accessed child and its index,
+	// valid as long as the parent version is
+	// cursorVersion. It make sequential access O(1).
+	cursor        Node
+	cursorIndex   int
+	cursorVersion uint64
 }
 
-func (nl *nodeList) set(index int, node Node) {
-	nl.list[index] = node
+func newChildNodeList(parent *node) *childNodeList {
+	return &childNodeList{
+		parent: parent,
+	}
 }
 
 /*****************************************************
@@ -47,7 +55,12 @@ func (nl *nodeList) set(index int, node Node) {
 
 // Length method return the number of node in the list
 func (nl *nodeList) Length() int {
-	return len(nl.Values())
+	return len(nl.list)
+}
+
+// Length method return the number of node in the list
+func (cl *childNodeList) Length() int {
+	return cl.parent.childCount
 }
 
 /*****************************************************
@@ -58,7 +71,7 @@ func (nl *nodeList) Length() int {
 // ForEach apply the given function for each of
 // the Node in the list.
 func (nl *nodeList) ForEach(fn func(i int, c Node)) {
-	for i, v := range nl.Values() {
+	for i, v := range nl.list {
 		fn(i, v)
 	}
 }
@@ -66,7 +79,7 @@ func (nl *nodeList) ForEach(fn func(i int, c Node)) {
 // IndexOf method return the index of the
 // searched node and return -1 if not found.
 func (nl *nodeList) IndexOf(searched Node) int {
-	for index, node := range nl.Values() {
+	for index, node := range nl.list {
 		if same := node.IsSameNode(searched); same {
 			return index
 		}
@@ -78,7 +91,7 @@ func (nl *nodeList) IndexOf(searched Node) int {
 // Item return a node from the Node list by index
 func (nl *nodeList) Item(index int) Node {
 	if index >= 0 && index < nl.Length() {
-		return nl.Values()[index]
+		return nl.list[index]
 	}
 	return nil
 }
@@ -89,3 +102,84 @@ func (nl *nodeList) Item(index int) Node {
 func (nl *nodeList) Values() []Node {
 	return nl.list
 }
+
+// ForEach apply the given function for each of
+// the Node in the list.
+func (cl *childNodeList) ForEach(fn func(i int, c Node)) {
+	i := 0
+	for child := cl.parent.firstChild; child != nil; child = child.NextSibling() {
+		fn(i, child)
+		i++
+	}
+}
+
+// IndexOf method return the index of the
+// searched node and return -1 if not found.
+func (cl *childNodeList) IndexOf(searched Node) int {
+	if searched == nil || !isChildOf(searched, cl.parent.self) {
+		return -1
+	}
+
+	// Counting the previous siblings
+	index := 0
+	for sibling := searched.PreviousSibling(); sibling != nil; sibling = sibling.PreviousSibling() {
+		index++
+	}
+
+	return index
+}
+
+// Item return a node from the Node list by index
+func (cl *childNodeList) Item(index int) Node {
+	if index < 0 || index >= cl.Length() {
+		return nil
+	}
+
+	// Walking from the nearest of the first child,
+	// last child and cursor.
+	var child Node
+	var from int
+	switch {
+	case cl.cursor != nil && cl.cursorVersion == cl.parent.version() &&
+		abs(index-cl.cursorIndex) < index && abs(index-cl.cursorIndex) < cl.Length()-1-index:
+		child, from = cl.cursor, cl.cursorIndex
+
+	case index < cl.Length()-1-index:
+		child, from = cl.parent.firstChild, 0
+
+	default:
+		child, from = cl.parent.lastChild, cl.Length()-1
+	}
+
+	for ; from < index; from++ {
+		child = child.NextSibling()
+	}
+	for ; from > index; from-- {
+		child = child.PreviousSibling()
+	}
+
+	cl.cursor, cl.cursorIndex, cl.cursorVersion = child, index, cl.parent.version()
+
+	return child
+}
+
+// Values method returns a snapshot of the nodes
+// contained in this object.
+// https://developer.mozilla.org/en-US/docs/Web/API/NodeList/values
+func (cl *childNodeList) Values() []Node {
+	values := make([]Node, 0, cl.Length())
+
+	for child := cl.parent.firstChild; child != nil; child = child.NextSibling() {
+		values = append(values, child)
+	}
+
+	return values
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+
+	return x
+}
@@ -2,7 +2,10 @@ package gom
 
 import (
 	"math/rand"
+	"sort"
 	"testing"
+
+	e "github.com/negrel/gom/exception"
 )
 
 /*****************************************************
@@ -15,7 +18,7 @@ func TestChildNodes(t *testing.T) {
 	child := newNode()
 
 	// Appending child
-	child = node.AppendChild(child)
+	child, _ = node.AppendChild(child)
 
 	// Getting children node list of node.
 	childNodes := node.ChildNodes()
@@ -38,7 +41,7 @@ func TestAppendChild(t *testing.T) {
 	child := node.CloneNode(false)
 
 	// Appending the child
-	child = node.AppendChild(child)
+	child, _ = node.AppendChild(child)
 
 	// Check that node contains the child
 	if contain := node.Contains(child); !contain {
@@ -62,7 +65,7 @@ func TestCloneNode(t *testing.T) {
 	child2 := newNode()
 
 	node.AppendChild(child2)
-	child = node.AppendChild(child)
+	child, _ = node.AppendChild(child)
 
 	// Clone the node but not his childs
 	clone := node.CloneNode(false)
@@ -104,7 +107,73 @@ func TestCloneNode(t *testing.T) {
 }
 
 func TestCompareDocumentPosition(t *testing.T) {
-	// TODO func TestCompareDocumentPosition(t *testing.T)
+	doc := parseTestDocument(t, `<div><p id="p"><b></b></p><i></i></div>`)
+	div := doc.DocumentElement()
+	p := doc.GetElementById("p")
+	b, i := p.FirstElementChild(), div.LastElementChild()
+
+	tests := []struct {
+		name     string
+		node     Node
+		other    Node
+		expected int
+	}{
+		{"same node", p, p, 0},
+		{"ancestor", b, div, DocumentPositionContains | DocumentPositionPreceding},
+		{"descendant", div, b, DocumentPositionContainedBy | DocumentPositionFollowing},
+		{"following", b, i, DocumentPositionFollowing},
+		{"preceding", i, b, DocumentPositionPreceding},
+		{"document", p, doc, DocumentPositionContains | DocumentPositionPreceding},
+		{"attribute owner", p.GetAttribute("id"), p, DocumentPositionContains | DocumentPositionPreceding},
+		{"owner attribute", p, p.GetAttribute("id"), DocumentPositionContainedBy | DocumentPositionFollowing},
+		{"attribute following", p.GetAttribute("id"), i, DocumentPositionFollowing},
+	}
+
+	for _, test := range tests {
+		if position := test.node.CompareDocumentPosition(test.other); position != test.expected {
+			t.Logf("%v: expected position %b, got %b.", test.name, test.expected, position)
+			t.Fail()
+		}
+	}
+
+	// Attributes of the same element
+	p.SetAttribute("class", "a")
+	class, id := p.GetAttribute("class"), p.GetAttribute("id")
+	position := class.CompareDocumentPosition(id)
+	if position&DocumentPositionImplementationSpecific == 0 ||
+		position&(DocumentPositionPreceding|DocumentPositionFollowing) == 0 ||
+		id.CompareDocumentPosition(class)&^position&(DocumentPositionPreceding|DocumentPositionFollowing) == 0 {
+		t.Logf("Attributes of the same element must be consistently ordered, got %b.", position)
+		t.Fail()
+	}
+
+	// Tree modification
+	div.InsertBefore(i, p)
+	if position := b.CompareDocumentPosition(i); position != DocumentPositionPreceding {
+		t.Logf("Position must be updated after tree modification, got %b.", position)
+		t.Fail()
+	}
+
+	// Disconnected
+	detached := doc.CreateElement("span")
+	position = detached.CompareDocumentPosition(p)
+	if position&DocumentPositionDisconnected == 0 || position&DocumentPositionImplementationSpecific == 0 {
+		t.Logf("Disconnected nodes position must be disconnected, got %b.", position)
+		t.Fail()
+	}
+
+	reverse := p.CompareDocumentPosition(detached)
+	if position&(DocumentPositionPreceding|DocumentPositionFollowing) ==
+		reverse&(DocumentPositionPreceding|DocumentPositionFollowing) {
+		t.Log("Disconnected nodes must be consistently ordered.")
+		t.Fail()
+	}
+
+	div.RemoveChild(p)
+	if position := p.CompareDocumentPosition(i); position&DocumentPositionDisconnected == 0 {
+		t.Logf("Removed node must be disconnected, got %b.", position)
+		t.Fail()
+	}
 }
 
 func TestContains(t *testing.T) {
@@ -112,7 +181,7 @@ func TestContains(t *testing.T) {
 	child1 := newNode()
 	child2 := child1.CloneNode(false)
 
-	child1 = node.AppendChild(child1)
+	child1, _ = node.AppendChild(child1)
 
 	// Checking that child1 is equal child2
 	if equal := child1.IsEqualNode(child2); !equal {
@@ -128,7 +197,7 @@ func TestContains(t *testing.T) {
 		t.Fail()
 	}
 
-	child2 = child1.AppendChild(child2)
+	child2, _ = child1.AppendChild(child2)
 
 	// Checking that child1 contain child2
 	if contain := child1.Contains(child2); !contain {
@@ -153,7 +222,7 @@ func TestGetRootNode(t *testing.T) {
 	child1 := newNode()
 	child2 := newNode()
 
-	child1 = node.AppendChild(child1)
+	child1, _ = node.AppendChild(child1)
 
 	// Checking that node root is node
 	if same := node.GetRootNode().IsSameNode(node); !same {
@@ -217,7 +286,7 @@ func TestInsertBefore(t *testing.T) {
 
 	// The node to insert
 	new := newNode()
-	new = node.InsertBefore(new, reference)
+	new, _ = node.InsertBefore(new, reference)
 
 	// Check if the node to insert is at the
 	// good index
@@ -268,14 +337,37 @@ func TestIsEqualNode(t *testing.T) {
 }
 
 func TestNormalize(t *testing.T) {
-	// TODO func TestNormalize(t *testing.T)
+	doc := parseTestDocument(t, `<div><p></p></div>`)
+	div := doc.DocumentElement()
+	p := div.FirstElementChild()
+
+	div.Append("", "a", "b", doc.CreateComment("c"), "", "d", "")
+	p.Append("e", "", "f")
+	first := div.ChildNodes().Item(2)
+
+	div.Normalize()
+
+	if div.InnerGOML() != "<p>ef</p>ab<!--c-->d" {
+		t.Logf("Text nodes must be merged and empty ones removed, got %v.", div.InnerGOML())
+		t.Fail()
+	}
+
+	if div.ChildNodes().Length() != 4 || p.ChildNodes().Length() != 1 {
+		t.Log("Merged text nodes must be removed.")
+		t.Fail()
+	}
+
+	if !div.ChildNodes().Item(1).IsSameNode(first) {
+		t.Log("Text nodes must be merged into the first one.")
+		t.Fail()
+	}
 }
 
 func TestRemoveChild(t *testing.T) {
 	node := newNode()
 	child := newNode()
 
-	child = node.AppendChild(child)
+	child, _ = node.AppendChild(child)
 
 	// Checking that node contains the child
 	if contain := node.Contains(child); !contain {
@@ -304,7 +396,7 @@ func TestRemoveChild(t *testing.T) {
 
 	// Checking that the node doesn't
 	// contain the child anymore
-	if contain := node.Contains(child); !contain {
+	if contain := node.Contains(child); contain {
 		t.Log("Node must not contain the child.")
 		t.Fail()
 	}
@@ -313,7 +405,7 @@ func TestRemoveChild(t *testing.T) {
 	 * Testing error
 	 */
 
-	child = node.AppendChild(child)
+	child, _ = node.AppendChild(child)
 
 	_, err = node.RemoveChild(nil)
 
@@ -327,7 +419,7 @@ func TestReplaceChild(t *testing.T) {
 	node := newNode()
 	child := newNode()
 
-	child = node.AppendChild(child)
+	child, _ = node.AppendChild(child)
 
 	child2 := node.CloneNode(true)
 
@@ -350,3 +442,276 @@ func TestReplaceChild(t *testing.T) {
 		t.Fail()
 	}
 }
+
+func TestPreInsertionValidity(t *testing.T) {
+	doc := NewDocument("goml")
+	html := doc.CreateElement("html")
+	body := doc.CreateElement("body")
+	text := doc.CreateTextNode("text")
+
+	if _, err := doc.AppendChild(html); err != nil {
+		t.Fatalf("Appending the document element must not return an error : %v", err)
+	}
+	html.AppendChild(body)
+
+	tests := []struct {
+		name     string
+		parent   Node
+		node     Node
+		child    Node
+		expected string
+	}{
+		{"node in its own descendant", body, html, nil, "HierarchyRequestError"},
+		{"node in itself", body, body, nil, "HierarchyRequestError"},
+		{"document as child", body, NewDocument("goml"), nil, "HierarchyRequestError"},
+		{"attribute as child", body, doc.CreateAttribute("id"), nil, "HierarchyRequestError"},
+		{"child of a text", text, doc.CreateElement("p"), nil, "HierarchyRequestError"},
+		{"text in document", doc, doc.CreateTextNode("text"), nil, "HierarchyRequestError"},
+		{"second document element", doc, doc.CreateElement("p"), nil, "HierarchyRequestError"},
+		{"second doctype", doc, newDocumentType("goml"), nil, "HierarchyRequestError"},
+		{"doctype in element", body, newDocumentType("goml"), nil, "HierarchyRequestError"},
+		{"reference not a child", html, doc.CreateElement("p"), text, "NotFoundError"},
+		{"nil node", body, nil, nil, "TypeError"},
+	}
+
+	for _, test := range tests {
+		var err e.Exception
+		if test.child == nil {
+			_, err = test.parent.AppendChild(test.node)
+		} else {
+			_, err = test.parent.InsertBefore(test.node, test.child)
+		}
+
+		if err == nil || err.Name() != test.expected {
+			t.Logf("Inserting %v must return a %v exception, got %v.", test.name, test.expected, err)
+			t.Fail()
+		}
+	}
+
+	// Comments are valid document children
+	if _, err := doc.InsertBefore(doc.CreateComment("comment"), html); err != nil {
+		t.Logf("Inserting a comment in a document must not return an error : %v", err)
+		t.Fail()
+	}
+
+	// Replacing the document element by another element
+	if err := doc.ReplaceChild(doc.CreateElement("html"), html); err != nil {
+		t.Logf("Replacing the document element must not return an error : %v", err)
+		t.Fail()
+	}
+}
+
+func TestMoveNode(t *testing.T) {
+	doc := parseTestDocument(t, `<div><ul id="a"><li>1</li><li>2</li><li>3</li></ul><ul id="b"></ul></div>`)
+	a, b := doc.GetElementById("a"), doc.GetElementById("b")
+	li := a.FirstChild()
+
+	// Moving to another parent
+	if _, err := b.AppendChild(li); err != nil {
+		t.Fatalf("Moving a node must not return an error : %v", err)
+	}
+
+	if a.ChildNodes().Length() != 2 || a.ChildNodes().IndexOf(li) != -1 {
+		t.Log("Moved node must be removed from its old parent child nodes.")
+		t.Fail()
+	}
+
+	if b.ChildNodes().Length() != 1 || !li.ParentNode().IsSameNode(b) || !li.ParentElement().IsSameNode(b) {
+		t.Log("Moved node must be a child of its new parent.")
+		t.Fail()
+	}
+
+	// Moving inside the same parent
+	two, three := a.FirstChild(), a.LastChild()
+	a.InsertBefore(three, two)
+
+	if a.ChildNodes().Length() != 2 || !a.FirstChild().IsSameNode(three) || !a.LastChild().IsSameNode(two) {
+		t.Logf("Node must be moved before its previous sibling, got %v.", a.InnerGOML())
+		t.Fail()
+	}
+
+	// Inserting a node before itself doesn't change anything
+	a.InsertBefore(three, three)
+	if a.InnerGOML() != "<li>3</li><li>2</li>" {
+		t.Logf("Inserting a node before itself must not change the tree, got %v.", a.InnerGOML())
+		t.Fail()
+	}
+
+	// Replacing a child by a node of another parent
+	a.ReplaceChild(li, two)
+	if a.InnerGOML() != "<li>3</li><li>1</li>" || b.HasChildNodes() || two.ParentNode() != nil {
+		t.Logf("Replacing node must be moved from its old parent, got %v and %v.", a.InnerGOML(), b.InnerGOML())
+		t.Fail()
+	}
+}
+
+func TestTextContent(t *testing.T) {
+	doc := parseTestDocument(t, `<div>Hello <b>World<!--comment--></b>!</div>`)
+	div := doc.DocumentElement()
+
+	if content := div.TextContent(); content != "Hello World!" {
+		t.Logf("Element text content must be the descendant texts data, got %q.", content)
+		t.Fail()
+	}
+
+	if content := div.FirstChild().TextContent(); content != "Hello " {
+		t.Logf("Text text content must be its data, got %q.", content)
+		t.Fail()
+	}
+
+	div.SetAttribute("class", "a")
+	if content := div.GetAttribute("class").TextContent(); content != "a" {
+		t.Logf("Attr text content must be its value, got %q.", content)
+		t.Fail()
+	}
+
+	if content := doc.TextContent(); content != "" {
+		t.Logf("Document text content must be empty, got %q.", content)
+		t.Fail()
+	}
+
+	fragment := doc.CreateDocumentFragment()
+	fragment.Append("a", doc.CreateElement("p"), "b")
+	fragment.FirstElementChild().Append("c")
+	if content := fragment.TextContent(); content != "acb" {
+		t.Logf("DocumentFragment text content must be the descendant texts data, got %q.", content)
+		t.Fail()
+	}
+}
+
+func TestSetTextContent(t *testing.T) {
+	doc := parseTestDocument(t, `<div>Hello <b>World</b>!</div>`)
+	div := doc.DocumentElement()
+	b := div.FirstElementChild()
+
+	div.SetTextContent("<new>")
+
+	if div.ChildNodes().Length() != 1 || div.FirstChild().NodeType() != TextNode {
+		t.Fatal("Element children must be replaced by a single Text node.")
+	}
+
+	if div.TextContent() != "<new>" || div.InnerGOML() != "&lt;new&gt;" {
+		t.Logf("Element text content must be set, got %q.", div.InnerGOML())
+		t.Fail()
+	}
+
+	if b.ParentNode() != nil || div.FirstChild().OwnerDocument() != doc {
+		t.Log("Children must be removed and the Text node must be owned by the document.")
+		t.Fail()
+	}
+
+	div.SetTextContent("")
+	if div.HasChildNodes() {
+		t.Log("Empty text content must remove all children.")
+		t.Fail()
+	}
+
+	text := doc.CreateTextNode("a")
+	text.SetTextContent("b")
+	if text.Data() != "b" {
+		t.Log("Text text content must set its data.")
+		t.Fail()
+	}
+
+	doc.SetTextContent("a")
+	if !doc.DocumentElement().IsSameNode(div) {
+		t.Log("Setting Document text content must do nothing.")
+		t.Fail()
+	}
+}
+
+func BenchmarkCompareDocumentPositionSort(b *testing.B) {
+	doc := NewDocument("goml")
+	root := doc.CreateElement("div")
+	doc.AppendChild(root)
+
+	nodes := make([]Node, 0, 1000)
+	for i := 0; i < 100; i++ {
+		section := doc.CreateElement("section")
+		root.AppendChild(section)
+
+		for j := 0; j < 10; j++ {
+			p := doc.CreateElement("p")
+			section.AppendChild(p)
+			nodes = append(nodes, p)
+		}
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		rand.Shuffle(len(nodes), func(i, j int) {
+			nodes[i], nodes[j] = nodes[j], nodes[i]
+		})
+
+		sort.Slice(nodes, func(i, j int) bool {
+			return nodes[i].CompareDocumentPosition(nodes[j])&DocumentPositionFollowing != 0
+		})
+	}
+}
+
+// wideTreeSize is the number of children of the parent
+// used by the wide tree benchmarks.
+const wideTreeSize = 100000
+
+// newWideTree return a parent with wideTreeSize children.
+func newWideTree() (Node, []Node) {
+	parent := newNode()
+	children := make([]Node, wideTreeSize)
+
+	for i := range children {
+		children[i] = newNode()
+		parent.AppendChild(children[i])
+	}
+
+	return parent, children
+}
+
+func BenchmarkWideTreeAppendChild(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		newWideTree()
+	}
+}
+
+func BenchmarkWideTreeInsertBefore(b *testing.B) {
+	parent, children := newWideTree()
+	middle := children[wideTreeSize/2]
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		parent.InsertBefore(newNode(), middle)
+	}
+}
+
+func BenchmarkWideTreeRemoveChild(b *testing.B) {
+	parent, children := newWideTree()
+	middle := children[wideTreeSize/2]
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		parent.RemoveChild(middle)
+		parent.AppendChild(middle)
+	}
+}
+
+func BenchmarkWideTreeSiblings(b *testing.B) {
+	parent, _ := newWideTree()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for child := parent.FirstChild(); child != nil; child = child.NextSibling() {
+			child.PreviousSibling()
+		}
+	}
+}
+
+func BenchmarkWideTreeItem(b *testing.B) {
+	parent, _ := newWideTree()
+	childNodes := parent.ChildNodes()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for j := 0; j < childNodes.Length(); j++ {
+			childNodes.Item(j)
+		}
+	}
+}
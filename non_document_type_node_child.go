@@ -15,7 +15,13 @@ type NonDocumentTypeChildNode interface {
 var _ NonDocumentTypeChildNode = &nonDocumentTypeChildNode{}
 
 type nonDocumentTypeChildNode struct {
-	node
+	self Node
+}
+
+func newNonDocumentTypeChildNode(self Node) *nonDocumentTypeChildNode {
+	return &nonDocumentTypeChildNode{
+		self: self,
+	}
 }
 
 /*****************************************************
@@ -24,7 +30,7 @@ type nonDocumentTypeChildNode struct {
 // ANCHOR Getters & Setters
 
 func (ndtcn *nonDocumentTypeChildNode) PreviousElementSibling() Element {
-	var node Node = ndtcn
+	var node Node = ndtcn.self
 
 	for {
 		prvSib := node.PreviousSibling()
@@ -47,7 +53,7 @@ func (ndtcn *nonDocumentTypeChildNode) PreviousElementSibling() Element {
 }
 
 func (ndtcn *nonDocumentTypeChildNode) NextElementSibling() Element {
-	var node Node = ndtcn
+	var node Node = ndtcn.self
 
 	for {
 		prvSib := node.NextSibling()
@@ -1,17 +1,53 @@
 package gom
 
+import e "github.com/negrel/gom/exception"
+
 // ParentNode mixin contains methods and properties
 // that are common to all types of Node objects that can have children
+// The nodes arguments are either Node or string, the
+// strings are converted into Text nodes.
 // https://developer.mozilla.org/en-US/docs/Web/API/ParentNode
 // https://dom.spec.whatwg.org/#parentnode
-type ParentNode struct {
+type ParentNode interface {
+	/* GETTERS & SETTERS (props) */
+	ChildElementCount() int
+	Children() GOMLCollection
+	FirstElementChild() Element
+	LastElementChild() Element
+	/* METHODS */
+	Append(nodes ...interface{}) e.Exception
+	Prepend(nodes ...interface{}) e.Exception
+	QuerySelector(selector string) (Element, e.Exception)
+	QuerySelectorAll(selector string) (NodeList, e.Exception)
+	ReplaceChildren(nodes ...interface{}) e.Exception
+}
+
+var _ ParentNode = &parentNodeMixin{}
+
+// parentNodeMixin implements the ParentNode mixin,
+// the parentNode name is already used by the node
+// parent field.
+type parentNodeMixin struct {
+	self     Node
 	children GOMLCollection
 }
 
-func newParentNode() *ParentNode {
-	return &ParentNode{
-		children: newGOMLCollection(),
+func newParentNode(self Node) *parentNodeMixin {
+	return &parentNodeMixin{
+		self:     self,
+		children: newChildrenCollection(self),
+	}
+}
+
+// nodeDocument return the document of the node, that is
+// the node itself for documents.
+// https://dom.spec.whatwg.org/#concept-node-document
+func nodeDocument(n Node) Document {
+	if doc, isDocument := n.(Document); isDocument {
+		return doc
 	}
+
+	return n.OwnerDocument()
 }
 
 /*****************************************************
@@ -19,20 +55,50 @@ func newParentNode() *ParentNode {
  *****************************************************/
 // ANCHOR Getters & Setters
 
+// ChildElementCount returns the number of child elements.
+// https://developer.mozilla.org/en-US/docs/Web/API/ParentNode/childElementCount
+func (pn *parentNodeMixin) ChildElementCount() int {
+	count := 0
+	for child := pn.self.FirstChild(); child != nil; child = child.NextSibling() {
+		if child.NodeType() == ElementNode {
+			count++
+		}
+	}
+
+	return count
+}
+
+// Children returns a live GOMLCollection containing the
+// child elements.
+// https://developer.mozilla.org/en-US/docs/Web/API/ParentNode/children
+func (pn *parentNodeMixin) Children() GOMLCollection {
+	return pn.children
+}
+
 // FirstElementChild returns the object's first child
 // Element, or null if there are no child elements.
 // https://developer.mozilla.org/en-US/docs/Web/API/ParentNode/firstElementChild
-func (pn *ParentNode) FirstElementChild() Element {
-	return pn.children.Item(0)
+func (pn *parentNodeMixin) FirstElementChild() Element {
+	for child := pn.self.FirstChild(); child != nil; child = child.NextSibling() {
+		if child.NodeType() == ElementNode {
+			return child.(Element)
+		}
+	}
+
+	return nil
 }
 
 // LastElementChild returns the object's last child
 // Element, or null if there are no child elements.
 // https://developer.mozilla.org/en-US/docs/Web/API/ParentNode/lastElementChild
-func (pn *ParentNode) LastElementChild() Element {
-	var lastIndex = pn.children.Length() - 1
+func (pn *parentNodeMixin) LastElementChild() Element {
+	for child := pn.self.LastChild(); child != nil; child = child.PreviousSibling() {
+		if child.NodeType() == ElementNode {
+			return child.(Element)
+		}
+	}
 
-	return pn.children.Item(lastIndex)
+	return nil
 }
 
 /*****************************************************
@@ -43,17 +109,27 @@ func (pn *ParentNode) LastElementChild() Element {
 // Append inserts a set of Node objects or DOMString
 // objects after the last child of the ParentNode
 // https://developer.mozilla.org/en-US/docs/Web/API/ParentNode/append
-func (pn *ParentNode) Append() {
-	// TODO func (pn *ParentNode) Append(nodes ...Node)
-	// https://dom.spec.whatwg.org/#dom-parentnode-append
+func (pn *parentNodeMixin) Append(nodes ...interface{}) e.Exception {
+	node, err := convertNodesIntoNode(nodeDocument(pn.self), nodes)
+	if err != nil {
+		return err
+	}
+
+	_, err = pn.self.AppendChild(node)
+	return err
 }
 
 // Prepend inserts a set of Node objects or DOMString
 // objects before the first child of the ParentNode
 // https://developer.mozilla.org/en-US/docs/Web/API/ParentNode/prepend
-func (pn *ParentNode) Prepend(nodes ...Node) {
-	// TODO func (pn *ParentNode) Prepend(nodes ...Node)
-	// https://dom.spec.whatwg.org/#dom-parentnode-prepend
+func (pn *parentNodeMixin) Prepend(nodes ...interface{}) e.Exception {
+	node, err := convertNodesIntoNode(nodeDocument(pn.self), nodes)
+	if err != nil {
+		return err
+	}
+
+	_, err = pn.self.InsertBefore(node, pn.self.FirstChild())
+	return err
 }
 
 // QuerySelector returns the first Element within
@@ -61,14 +137,32 @@ func (pn *ParentNode) Prepend(nodes ...Node) {
 // or group of selectors. If no matches are found,
 // null is returned.
 // https://developer.mozilla.org/en-US/docs/Web/API/Document/querySelector
-func (pn *ParentNode) QuerySelector(selector string) {
-	// TODO func (d *Document) QuerySelector(selector string)
+func (pn *parentNodeMixin) QuerySelector(selector string) (Element, e.Exception) {
+	return querySelector(pn.self, selector)
 }
 
 // QuerySelectorAll returns a static (not live) NodeList
 // representing a list of the document's elements that
 // match the specified group of selectors.
 // https://developer.mozilla.org/en-US/docs/Web/API/Document/querySelectorAll
-func (pn *ParentNode) QuerySelectorAll(selector string) {
-	// TODO func (d *Document) QuerySelectorAll(selector string)
+func (pn *parentNodeMixin) QuerySelectorAll(selector string) (NodeList, e.Exception) {
+	return querySelectorAll(pn.self, selector)
+}
+
+// ReplaceChildren replaces the existing children with a
+// set of Node objects or DOMString objects.
+// https://developer.mozilla.org/en-US/docs/Web/API/ParentNode/replaceChildren
+func (pn *parentNodeMixin) ReplaceChildren(nodes ...interface{}) e.Exception {
+	node, err := convertNodesIntoNode(nodeDocument(pn.self), nodes)
+	if err != nil {
+		return err
+	}
+
+	if err := ensurePreInsertionValidity(pn.self, node, nil); err != nil {
+		return err
+	}
+
+	pn.self.replaceAll(node)
+
+	return nil
 }
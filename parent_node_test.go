@@ -0,0 +1,129 @@
+package gom
+
+import (
+	"testing"
+)
+
+func TestParentNodeAppendPrepend(t *testing.T) {
+	doc := parseTestDocument(t, `<div><b></b></div>`)
+	div := doc.DocumentElement()
+
+	if err := div.Append("x", doc.CreateElement("p")); err != nil {
+		t.Fatalf("Append must not return an error : %v", err)
+	}
+
+	if err := div.Prepend(doc.CreateElement("i"), "y"); err != nil {
+		t.Fatalf("Prepend must not return an error : %v", err)
+	}
+
+	if div.InnerGOML() != "<i></i>y<b></b>x<p></p>" {
+		t.Logf("Nodes must be prepended and appended in order, got %v.", div.InnerGOML())
+		t.Fail()
+	}
+
+	if err := div.Append(nil); err == nil || err.Name() != "TypeError" {
+		t.Log("Invalid argument type must return a TypeError exception.")
+		t.Fail()
+	}
+
+	if err := div.Append(div); err == nil || err.Name() != "HierarchyRequestError" {
+		t.Log("Appending an ancestor must return a HierarchyRequestError exception.")
+		t.Fail()
+	}
+
+	// Fragment and document
+	fragment := doc.CreateDocumentFragment()
+	fragment.Append("text")
+	if fragment.FirstChild() == nil || fragment.FirstChild().(Text).Data() != "text" {
+		t.Log("DocumentFragment Append must append a Text node.")
+		t.Fail()
+	}
+
+	if err := doc.Append("text"); err == nil {
+		t.Log("Text must not be appended to a document.")
+		t.Fail()
+	}
+}
+
+func TestParentNodeReplaceChildren(t *testing.T) {
+	doc := parseTestDocument(t, `<div><b></b>text<i></i></div>`)
+	div := doc.DocumentElement()
+	b := div.FirstChild()
+
+	if err := div.ReplaceChildren(doc.CreateElement("p"), "new"); err != nil {
+		t.Fatalf("ReplaceChildren must not return an error : %v", err)
+	}
+
+	if div.InnerGOML() != "<p></p>new" || b.ParentNode() != nil {
+		t.Logf("Children must be replaced, got %v.", div.InnerGOML())
+		t.Fail()
+	}
+
+	// Invalid replacement keep the children
+	if err := div.ReplaceChildren(div); err == nil {
+		t.Log("Invalid replacement must return an exception.")
+		t.Fail()
+	}
+
+	if div.InnerGOML() != "<p></p>new" {
+		t.Logf("Invalid replacement must not remove the children, got %v.", div.InnerGOML())
+		t.Fail()
+	}
+
+	div.ReplaceChildren()
+	if div.HasChildNodes() {
+		t.Log("ReplaceChildren without argument must remove all children.")
+		t.Fail()
+	}
+}
+
+func TestParentNodeChildren(t *testing.T) {
+	doc := parseTestDocument(t, `<div>text<b><u></u></b><!--c--><i></i></div>`)
+	div := doc.DocumentElement()
+	children := div.Children()
+
+	if children.Length() != 2 || div.ChildElementCount() != 2 {
+		t.Fatalf("Children must only contain the child elements, got %v.", children.Length())
+	}
+
+	if div.FirstElementChild().TagName() != "b" || div.LastElementChild().TagName() != "i" {
+		t.Log("First and last element children are invalid.")
+		t.Fail()
+	}
+
+	// Live collection
+	div.Append(doc.CreateElement("p"))
+	div.FirstElementChild().Remove()
+
+	if children.Length() != 2 || children.Item(0).TagName() != "i" || children.Item(1).TagName() != "p" {
+		t.Log("Children collection must be updated with the child list.")
+		t.Fail()
+	}
+
+	if doc.ChildElementCount() != 1 || !doc.FirstElementChild().IsSameNode(div) {
+		t.Log("Document children must contain the document element.")
+		t.Fail()
+	}
+
+	// No child element
+	empty := doc.CreateElement("p")
+	empty.Append("text")
+	if empty.FirstElementChild() != nil || empty.LastElementChild() != nil || empty.ChildElementCount() != 0 {
+		t.Log("First and last element children must be nil without child elements.")
+		t.Fail()
+	}
+}
+
+func BenchmarkParentNodeFirstElementChild(b *testing.B) {
+	doc := NewDocument("goml")
+	div := doc.CreateElement("div")
+	for i := 0; i < wideTreeSize; i++ {
+		div.AppendChild(doc.CreateElement("p"))
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		div.Append("text")
+		div.FirstElementChild()
+	}
+}
@@ -0,0 +1,258 @@
+package gom
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+
+	e "github.com/negrel/gom/exception"
+)
+
+// ParseError is the SyntaxError exception returned when
+// parsing a malformed GOML markup. It hold the position
+// of the offending markup.
+type ParseError struct {
+	e.Exception
+	Line   int
+	Column int
+}
+
+// Error implements the error interface.
+func (pe *ParseError) Error() string {
+	return fmt.Sprintf("%v (line %v, column %v)", pe.String(), pe.Line, pe.Column)
+}
+
+// voidElements contains the elements that can't have
+// any child and thus have no end tag.
+var voidElements = map[string]bool{
+	"area":   true,
+	"base":   true,
+	"br":     true,
+	"col":    true,
+	"embed":  true,
+	"hr":     true,
+	"img":    true,
+	"input":  true,
+	"link":   true,
+	"meta":   true,
+	"param":  true,
+	"source": true,
+	"track":  true,
+	"wbr":    true,
+}
+
+// ParseDocument parse the GOML markup read from r and
+// return the resulting Document.
+// A *ParseError is returned if the markup is malformed.
+func ParseDocument(r io.Reader) (Document, error) {
+	src, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	doc := newDocument()
+
+	if err := newParser(string(src), doc, doc).parse(); err != nil {
+		return nil, err
+	}
+
+	return doc, nil
+}
+
+// parseFragment parse the GOML markup in the context of
+// the given element and return the resulting nodes.
+// The nodes are owned by the context owner document
+// and have no parent.
+func parseFragment(src string, context Element) ([]Node, *ParseError) {
+	doc := context.OwnerDocument()
+	if doc == nil {
+		doc = newDocument()
+	}
+
+	container := createElement(context.TagName())
+
+	// The content of raw text elements is not parsed
+	if rawTextElements[container.TagName()] {
+		if src != "" {
+			container.AppendChild(doc.CreateTextNode(src))
+		}
+	} else if err := newParser(src, doc, container).parse(); err != nil {
+		return nil, err
+	}
+
+	nodes := make([]Node, container.ChildNodes().Length())
+	copy(nodes, container.ChildNodes().Values())
+
+	for _, node := range nodes {
+		container.RemoveChild(node)
+		node.apply(func(n Node) {
+			n.SetOwnerDocument(context.OwnerDocument())
+		})
+	}
+
+	return nodes, nil
+}
+
+// openElement is an element whose end tag has not
+// been parsed yet.
+type openElement struct {
+	element Element
+	pos     position
+}
+
+// parser build a node tree from the tokens of a
+// GOML source.
+type parser struct {
+	*tokenizer
+	document Document
+	root     Node
+	stack    []openElement
+}
+
+// newParser return a parser appending the parsed nodes
+// to root. Nodes are created with the given document.
+func newParser(src string, doc Document, root Node) *parser {
+	return &parser{
+		tokenizer: newTokenizer(src),
+		document:  doc,
+		root:      root,
+		stack:     make([]openElement, 0),
+	}
+}
+
+// current return the node the parsed nodes are appended to.
+func (p *parser) current() Node {
+	if len(p.stack) == 0 {
+		return p.root
+	}
+
+	return p.stack[len(p.stack)-1].element
+}
+
+// atDocumentLevel report whether the parsed nodes are
+// appended directly to a Document.
+func (p *parser) atDocumentLevel() bool {
+	return len(p.stack) == 0 && p.root.NodeType() == DocumentNode
+}
+
+func (p *parser) parse() *ParseError {
+	for {
+		tok, err := p.next()
+		if err != nil {
+			return err
+		}
+
+		// End of source
+		if tok == nil {
+			break
+		}
+
+		if err := p.handle(tok); err != nil {
+			return err
+		}
+	}
+
+	// All elements must be closed
+	if len(p.stack) > 0 {
+		unclosed := p.stack[len(p.stack)-1]
+
+		return p.errorf(unclosed.pos, "unclosed <%v> element", unclosed.element.TagName())
+	}
+
+	return nil
+}
+
+// handle append the node corresponding to the token.
+func (p *parser) handle(tok *token) *ParseError {
+	switch tok.typ {
+	case doctypeToken:
+		return p.handleDoctype(tok)
+
+	case startTagToken, selfClosingTagToken:
+		return p.handleStartTag(tok)
+
+	case endTagToken:
+		return p.handleEndTag(tok)
+
+	case commentToken:
+		p.current().AppendChild(p.document.CreateComment(tok.data))
+
+	case textToken:
+		return p.handleText(tok)
+	}
+
+	return nil
+}
+
+func (p *parser) handleDoctype(tok *token) *ParseError {
+	if !p.atDocumentLevel() {
+		return p.errorf(tok.pos, "unexpected doctype")
+	}
+
+	if p.document.DocType() != nil {
+		return p.errorf(tok.pos, "duplicate doctype")
+	}
+
+	if p.document.DocumentElement() != nil {
+		return p.errorf(tok.pos, "doctype must precede the document element")
+	}
+
+	docType := newDocumentType(tok.data)
+	docType.SetOwnerDocument(p.document)
+	p.root.AppendChild(docType)
+
+	return nil
+}
+
+func (p *parser) handleStartTag(tok *token) *ParseError {
+	if p.atDocumentLevel() && p.document.DocumentElement() != nil {
+		return p.errorf(tok.pos, "unexpected <%v> element, document already has a root element", tok.data)
+	}
+
+	element := p.document.CreateElement(tok.data)
+	for _, attr := range tok.attrs {
+		element.SetAttribute(attr.name, attr.value)
+	}
+
+	p.current().AppendChild(element)
+
+	if tok.typ == startTagToken && !voidElements[tok.data] {
+		p.stack = append(p.stack, openElement{
+			element: element,
+			pos:     tok.pos,
+		})
+	}
+
+	return nil
+}
+
+func (p *parser) handleEndTag(tok *token) *ParseError {
+	if len(p.stack) == 0 {
+		return p.errorf(tok.pos, "unexpected </%v> end tag", tok.data)
+	}
+
+	open := p.stack[len(p.stack)-1]
+	if open.element.TagName() != tok.data {
+		return p.errorf(tok.pos, "unexpected </%v> end tag, expected </%v>", tok.data, open.element.TagName())
+	}
+
+	p.stack = p.stack[:len(p.stack)-1]
+
+	return nil
+}
+
+func (p *parser) handleText(tok *token) *ParseError {
+	if p.atDocumentLevel() {
+		// Whitespaces between document children are ignored.
+		if strings.TrimSpace(tok.data) == "" {
+			return nil
+		}
+
+		return p.errorf(tok.pos, "unexpected text outside of the document element")
+	}
+
+	p.current().AppendChild(p.document.CreateTextNode(tok.data))
+
+	return nil
+}
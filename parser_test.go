@@ -0,0 +1,154 @@
+package gom
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestParseDocument(t *testing.T) {
+	file, err := os.Open("example/index.goml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	doc, err := ParseDocument(file)
+	if err != nil {
+		t.Fatalf("Error while parsing the document : %v", err)
+	}
+
+	// Checking the doctype
+	if docType := doc.DocType(); docType == nil || docType.Name() != "goml" {
+		t.Log("Document doctype must be \"goml\".")
+		t.Fail()
+	}
+
+	// Checking the document element
+	docEl := doc.DocumentElement()
+	if docEl == nil || docEl.TagName() != "html" {
+		t.Fatal("Document element must be the <html> element.")
+	}
+
+	if lang := docEl.GetAttribute("lang"); lang == nil || lang.Value() != "en" {
+		t.Log("Document element must have a lang attribute equal to \"en\".")
+		t.Fail()
+	}
+
+	// Checking head & body
+	if head := doc.Head(); head == nil || head.TagName() != "head" {
+		t.Log("Document head must be the <head> element.")
+		t.Fail()
+	}
+
+	body, isElement := doc.Body().(Element)
+	if !isElement || body.TagName() != "body" {
+		t.Fatal("Document body must be the <body> element.")
+	}
+
+	if same := body.ParentNode().IsSameNode(docEl); !same {
+		t.Log("Body parent node must be the document element.")
+		t.Fail()
+	}
+
+	// Checking the <meta> void element
+	meta := childElementByTagName(doc.Head(), "meta")
+	if meta == nil || meta.HasChildNodes() {
+		t.Log("Head must contain an empty <meta> element.")
+		t.Fail()
+	}
+
+	// Checking the <span> elements
+	div := childElementByTagName(body, "div")
+	span := childElementByTagName(div, "span")
+
+	if _, isSpan := span.(*GOMLSpanElement); !isSpan {
+		t.Logf("<span> element must be a GOMLSpanElement, got %T.", span)
+		t.Fail()
+	}
+
+	if text := span.FirstChild().(Text); text.Data() != "Hello" {
+		t.Logf("Span text must be \"Hello\", got %q.", text.Data())
+		t.Fail()
+	}
+
+	if same := span.OwnerDocument().IsSameNode(doc); !same {
+		t.Log("Span owner document must be the parsed document.")
+		t.Fail()
+	}
+}
+
+func TestParseDocumentCharacterReferences(t *testing.T) {
+	src := `<div title='a &amp; b' hidden><!-- note -->1 &lt; 2<br/></div>`
+
+	doc, err := ParseDocument(strings.NewReader(src))
+	if err != nil {
+		t.Fatalf("Error while parsing the document : %v", err)
+	}
+
+	div := doc.DocumentElement()
+
+	if title := div.GetAttribute("title").Value(); title != "a & b" {
+		t.Logf("Title attribute must be \"a & b\", got %q.", title)
+		t.Fail()
+	}
+
+	if !div.HasAttribute("hidden") {
+		t.Log("Div must have the hidden attribute.")
+		t.Fail()
+	}
+
+	children := div.ChildNodes()
+	if children.Length() != 3 {
+		t.Fatalf("Div must have 3 children, got %v.", children.Length())
+	}
+
+	if comment, isComment := children.Item(0).(Comment); !isComment || comment.Data() != " note " {
+		t.Log("First div child must be the \" note \" comment.")
+		t.Fail()
+	}
+
+	if text := children.Item(1).(Text); text.Data() != "1 < 2" {
+		t.Logf("Second div child must be \"1 < 2\", got %q.", text.Data())
+		t.Fail()
+	}
+}
+
+func TestParseDocumentError(t *testing.T) {
+	tests := []struct {
+		src    string
+		line   int
+		column int
+	}{
+		{"<div>\n  <span></div>", 2, 9},
+		{"<div>\n  <span>", 2, 3},
+		{"<div class=\"a></div>", 1, 6},
+		{"</div>", 1, 1},
+		{"text", 1, 1},
+		{"<div></div>\n<div></div>", 2, 1},
+		{"<div><!-- </div>", 1, 6},
+		{"<div></div><!DOCTYPE goml>", 1, 12},
+	}
+
+	for _, test := range tests {
+		_, err := ParseDocument(strings.NewReader(test.src))
+
+		parseErr, isParseErr := err.(*ParseError)
+		if !isParseErr {
+			t.Logf("Parsing %q must return a *ParseError, got %v.", test.src, err)
+			t.Fail()
+			continue
+		}
+
+		if parseErr.Name() != "SyntaxError" {
+			t.Logf("Parsing %q must return a SyntaxError, got %v.", test.src, parseErr.Name())
+			t.Fail()
+		}
+
+		if parseErr.Line != test.line || parseErr.Column != test.column {
+			t.Logf("Parsing %q error must be at %v:%v, got %v:%v.",
+				test.src, test.line, test.column, parseErr.Line, parseErr.Column)
+			t.Fail()
+		}
+	}
+}
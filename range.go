@@ -0,0 +1,1065 @@
+package gom
+
+import (
+	"strings"
+
+	e "github.com/negrel/gom/exception"
+)
+
+// AbstractRange is the base interface of the Range and
+// StaticRange objects.
+// https://developer.mozilla.org/en-US/docs/Web/API/AbstractRange
+// https://dom.spec.whatwg.org/#abstractrange
+type AbstractRange interface {
+	/* GETTERS & SETTERS (props) */
+	Collapsed() bool
+	EndContainer() Node
+	EndOffset() int
+	StartContainer() Node
+	StartOffset() int
+}
+
+// StaticRange represents a range which is not updated
+// when the tree is modified.
+// https://developer.mozilla.org/en-US/docs/Web/API/StaticRange
+// https://dom.spec.whatwg.org/#interface-staticrange
+type StaticRange interface {
+	/* EMBEDDED INTERFACE */
+	AbstractRange
+}
+
+// Range represents a fragment of a document that can
+// contain nodes and parts of text nodes. The range
+// boundaries are updated when the tree is modified until
+// the range is released: the document keeps a reference
+// to each range until its Release method is called.
+// https://developer.mozilla.org/en-US/docs/Web/API/Range
+// https://dom.spec.whatwg.org/#interface-range
+type Range interface {
+	/* EMBEDDED INTERFACE */
+	AbstractRange
+	/* GETTERS & SETTERS (props) */
+	CommonAncestorContainer() Node
+	/* METHODS */
+	CloneContents() (DocumentFragment, e.Exception)
+	CloneRange() Range
+	Collapse(toStart bool)
+	CompareBoundaryPoints(how int, source Range) (int, e.Exception)
+	ComparePoint(node Node, offset int) (int, e.Exception)
+	DeleteContents() e.Exception
+	Detach()
+	ExtractContents() (DocumentFragment, e.Exception)
+	InsertNode(node Node) e.Exception
+	IntersectsNode(node Node) bool
+	IsPointInRange(node Node, offset int) (bool, e.Exception)
+	Release()
+	SelectNode(node Node) e.Exception
+	SelectNodeContents(node Node) e.Exception
+	SetEnd(node Node, offset int) e.Exception
+	SetEndAfter(node Node) e.Exception
+	SetEndBefore(node Node) e.Exception
+	SetStart(node Node, offset int) e.Exception
+	SetStartAfter(node Node) e.Exception
+	SetStartBefore(node Node) e.Exception
+	SurroundContents(newParent Node) e.Exception
+	ToString() string
+}
+
+// The CompareBoundaryPoints how values.
+const (
+	RangeStartToStart = iota
+	RangeStartToEnd
+	RangeEndToEnd
+	RangeEndToStart
+)
+
+var _ StaticRange = &abstractRange{}
+var _ Range = &liveRange{}
+
+type abstractRange struct {
+	startContainer Node
+	startOffset    int
+	endContainer   Node
+	endOffset      int
+}
+
+// NewStaticRange return a new StaticRange. An
+// InvalidNodeTypeError exception is returned if a
+// container is a DocumentType or an Attr.
+// https://developer.mozilla.org/en-US/docs/Web/API/StaticRange/StaticRange
+func NewStaticRange(startContainer Node, startOffset int, endContainer Node, endOffset int) (StaticRange, e.Exception) {
+	for _, container := range []Node{startContainer, endContainer} {
+		if container == nil {
+			return nil, e.TypeError("A StaticRange container can't be nil.")
+		}
+		if nodeType := container.NodeType(); nodeType == DocumentTypeNode || nodeType == AttributeNode {
+			return nil, e.New(e.InvalidNodeTypeError, "A StaticRange container can't be a %v node.", container.NodeName())
+		}
+	}
+
+	return &abstractRange{
+		startContainer: startContainer,
+		startOffset:    startOffset,
+		endContainer:   endContainer,
+		endOffset:      endOffset,
+	}, nil
+}
+
+type liveRange struct {
+	*abstractRange
+	// document is the document in which the range is
+	// registered to be updated.
+	document Document
+	// released ranges are never registered.
+	released bool
+}
+
+// newRange return a new Range collapsed at the given
+// boundary point and registered in its node document.
+func newRange(node Node, offset int) *liveRange {
+	r := newUnregisteredRange(node, offset, node, offset)
+	r.released = false
+	r.register(nodeDocument(node))
+
+	return r
+}
+
+// newUnregisteredRange return a new Range that isn't
+// updated when the tree is modified.
+func newUnregisteredRange(startNode Node, startOffset int, endNode Node, endOffset int) *liveRange {
+	return &liveRange{
+		abstractRange: &abstractRange{
+			startContainer: startNode,
+			startOffset:    startOffset,
+			endContainer:   endNode,
+			endOffset:      endOffset,
+		},
+		released: true,
+	}
+}
+
+// liveRangesOf return the live ranges to update when the
+// node is modified.
+func liveRangesOf(n Node) map[*liveRange]struct{} {
+	if doc := nodeDocument(n); doc != nil {
+		return doc.liveRanges()
+	}
+
+	return nil
+}
+
+// register move the range to the live ranges of doc.
+func (r *liveRange) register(doc Document) {
+	if r.released || r.document == doc {
+		return
+	}
+
+	if r.document != nil {
+		delete(r.document.liveRanges(), r)
+	}
+	if doc != nil {
+		doc.liveRanges()[r] = struct{}{}
+	}
+
+	r.document = doc
+}
+
+/*****************************************************
+ ****************** Boundary points ******************
+ *****************************************************/
+// ANCHOR Boundary points
+
+// nodeLength return the length of the node.
+// https://dom.spec.whatwg.org/#concept-node-length
+func nodeLength(node Node) int {
+	switch n := node.(type) {
+	case DocumentType, Attr:
+		return 0
+	case CharacterData:
+		return n.Length()
+	}
+
+	return node.ChildNodes().Length()
+}
+
+// nodeIndex return the index of the node in its parent
+// children.
+// https://dom.spec.whatwg.org/#concept-tree-index
+func nodeIndex(node Node) int {
+	index := 0
+	for sibling := node.PreviousSibling(); sibling != nil; sibling = sibling.PreviousSibling() {
+		index++
+	}
+
+	return index
+}
+
+// isInclusiveAncestor report whether ancestor is node or
+// one of its ancestors.
+func isInclusiveAncestor(ancestor, node Node) bool {
+	return ancestor.IsSameNode(node) || ancestor.Contains(node)
+}
+
+// isCharacterData report whether node is a Text or a
+// Comment node.
+func isCharacterData(node Node) bool {
+	nodeType := node.NodeType()
+
+	return nodeType == TextNode || nodeType == CommentNode
+}
+
+// comparePoints return -1, 0 or 1 if the boundary point
+// (nodeA, offsetA) is before, equal or after the boundary
+// point (nodeB, offsetB). The nodes must have the same root.
+// https://dom.spec.whatwg.org/#concept-range-bp-position
+func comparePoints(nodeA Node, offsetA int, nodeB Node, offsetB int) int {
+	if nodeA.IsSameNode(nodeB) {
+		switch {
+		case offsetA < offsetB:
+			return -1
+		case offsetA > offsetB:
+			return 1
+		}
+
+		return 0
+	}
+
+	// nodeA is following nodeB
+	if nodeA.CompareDocumentPosition(nodeB)&DocumentPositionPreceding != 0 {
+		return -comparePoints(nodeB, offsetB, nodeA, offsetA)
+	}
+
+	// nodeA is an ancestor of nodeB
+	if nodeA.Contains(nodeB) {
+		child := nodeB
+		for !child.ParentNode().IsSameNode(nodeA) {
+			child = child.ParentNode()
+		}
+
+		if nodeIndex(child) < offsetA {
+			return 1
+		}
+	}
+
+	return -1
+}
+
+// root return the root of the range.
+func (r *abstractRange) root() Node {
+	return r.startContainer.GetRootNode()
+}
+
+// setStartAndEnd set both boundary points to the given one.
+func (r *abstractRange) setStartAndEnd(node Node, offset int) {
+	r.startContainer, r.startOffset = node, offset
+	r.endContainer, r.endOffset = node, offset
+}
+
+// contains report whether node is contained in the range.
+// https://dom.spec.whatwg.org/#contained
+func (r *abstractRange) contains(node Node) bool {
+	return node.GetRootNode().IsSameNode(r.root()) &&
+		comparePoints(node, 0, r.startContainer, r.startOffset) > 0 &&
+		comparePoints(node, nodeLength(node), r.endContainer, r.endOffset) < 0
+}
+
+// partiallyContains report whether node is partially
+// contained in the range.
+// https://dom.spec.whatwg.org/#partially-contained
+func (r *abstractRange) partiallyContains(node Node) bool {
+	return isInclusiveAncestor(node, r.startContainer) != isInclusiveAncestor(node, r.endContainer)
+}
+
+// containedNodes return the nodes contained in the range
+// whose parent isn't contained, in tree order.
+func (r *abstractRange) containedNodes() []Node {
+	var nodes []Node
+
+	var walk func(parent Node)
+	walk = func(parent Node) {
+		for child := parent.FirstChild(); child != nil; child = child.NextSibling() {
+			if r.contains(child) {
+				nodes = append(nodes, child)
+			} else {
+				walk(child)
+			}
+		}
+	}
+	walk(r.commonAncestorContainer())
+
+	return nodes
+}
+
+func (r *abstractRange) commonAncestorContainer() Node {
+	container := r.startContainer
+	for !isInclusiveAncestor(container, r.endContainer) {
+		container = container.ParentNode()
+	}
+
+	return container
+}
+
+// checkPoint return an exception if the boundary point is
+// invalid.
+func checkPoint(node Node, offset int) e.Exception {
+	if node == nil {
+		return e.TypeError("The boundary point node is nil.")
+	}
+
+	if node.NodeType() == DocumentTypeNode {
+		return e.New(e.InvalidNodeTypeError, "A range boundary point can't be in a DocumentType node.")
+	}
+
+	if offset < 0 || offset > nodeLength(node) {
+		return e.RangeError("The offset %v is larger than the node's length (%v).", offset, nodeLength(node))
+	}
+
+	return nil
+}
+
+// setBoundary set the start or end boundary point of the
+// range.
+// https://dom.spec.whatwg.org/#concept-range-bp-set
+func (r *liveRange) setBoundary(node Node, offset int, start bool) e.Exception {
+	if err := checkPoint(node, offset); err != nil {
+		return err
+	}
+
+	otherRoot := !node.GetRootNode().IsSameNode(r.root())
+
+	if start {
+		if otherRoot || comparePoints(node, offset, r.endContainer, r.endOffset) > 0 {
+			r.endContainer, r.endOffset = node, offset
+		}
+		r.startContainer, r.startOffset = node, offset
+	} else {
+		if otherRoot || comparePoints(node, offset, r.startContainer, r.startOffset) < 0 {
+			r.startContainer, r.startOffset = node, offset
+		}
+		r.endContainer, r.endOffset = node, offset
+	}
+
+	r.register(nodeDocument(node))
+
+	return nil
+}
+
+// setBoundaryBeside set the start or end boundary point of
+// the range before or after the given node.
+func (r *liveRange) setBoundaryBeside(node Node, after, start bool) e.Exception {
+	if node == nil {
+		return e.TypeError("The node is nil.")
+	}
+
+	parent := node.ParentNode()
+	if parent == nil {
+		return e.New(e.InvalidNodeTypeError, "The node has no parent.")
+	}
+
+	offset := nodeIndex(node)
+	if after {
+		offset++
+	}
+
+	return r.setBoundary(parent, offset, start)
+}
+
+/*****************************************************
+ ******************* Live updates ********************
+ *****************************************************/
+// ANCHOR Live updates
+
+// childInserted update the range before the insertion of
+// a node in parent before child, or at the end if child
+// is nil. The index of the insertion is only computed if
+// a boundary is in parent.
+// https://dom.spec.whatwg.org/#concept-node-insert
+func (r *liveRange) childInserted(parent, child Node) {
+	if !r.startContainer.IsSameNode(parent) && !r.endContainer.IsSameNode(parent) {
+		return
+	}
+
+	index := parent.ChildNodes().Length()
+	if child != nil {
+		index = nodeIndex(child)
+	}
+
+	if r.startContainer.IsSameNode(parent) && r.startOffset > index {
+		r.startOffset++
+	}
+	if r.endContainer.IsSameNode(parent) && r.endOffset > index {
+		r.endOffset++
+	}
+}
+
+// childRemoving update the range before the removal of
+// child from parent. The index of the child is only
+// computed if a boundary is in parent or in child.
+// https://dom.spec.whatwg.org/#concept-node-remove
+func (r *liveRange) childRemoving(parent, child Node) {
+	startRemoved := isInclusiveAncestor(child, r.startContainer)
+	endRemoved := isInclusiveAncestor(child, r.endContainer)
+
+	if !startRemoved && !endRemoved &&
+		!r.startContainer.IsSameNode(parent) && !r.endContainer.IsSameNode(parent) {
+		return
+	}
+
+	index := nodeIndex(child)
+
+	if startRemoved {
+		r.startContainer, r.startOffset = parent, index
+	}
+	if endRemoved {
+		r.endContainer, r.endOffset = parent, index
+	}
+
+	if r.startContainer.IsSameNode(parent) && r.startOffset > index {
+		r.startOffset--
+	}
+	if r.endContainer.IsSameNode(parent) && r.endOffset > index {
+		r.endOffset--
+	}
+}
+
+// dataReplaced update the range after count characters
+// at offset of node were replaced by length characters.
+// https://dom.spec.whatwg.org/#concept-cd-replace
+func (r *liveRange) dataReplaced(node Node, offset, count, length int) {
+	update := func(container Node, boundary *int) {
+		if !container.IsSameNode(node) || *boundary <= offset {
+			return
+		}
+
+		if *boundary <= offset+count {
+			*boundary = offset
+		} else {
+			*boundary += length - count
+		}
+	}
+
+	update(r.startContainer, &r.startOffset)
+	update(r.endContainer, &r.endOffset)
+}
+
+// textSplit update the range after the split of node at
+// offset, newNode being inserted after node in its parent.
+// https://dom.spec.whatwg.org/#concept-text-split
+func (r *liveRange) textSplit(node, newNode Node, offset int) {
+	if r.startContainer.IsSameNode(node) && r.startOffset > offset {
+		r.startContainer, r.startOffset = newNode, r.startOffset-offset
+	}
+	if r.endContainer.IsSameNode(node) && r.endOffset > offset {
+		r.endContainer, r.endOffset = newNode, r.endOffset-offset
+	}
+
+	parent := node.ParentNode()
+	index := nodeIndex(node) + 1
+
+	if r.startContainer.IsSameNode(parent) && r.startOffset == index {
+		r.startOffset++
+	}
+	if r.endContainer.IsSameNode(parent) && r.endOffset == index {
+		r.endOffset++
+	}
+}
+
+// textMerged update the range before the merge of the
+// data of merged in node, length being the data length
+// of node before the merge.
+// https://dom.spec.whatwg.org/#dom-node-normalize
+func (r *liveRange) textMerged(node, merged Node, length int) {
+	if r.startContainer.IsSameNode(merged) {
+		r.startContainer, r.startOffset = node, r.startOffset+length
+	}
+	if r.endContainer.IsSameNode(merged) {
+		r.endContainer, r.endOffset = node, r.endOffset+length
+	}
+
+	if parent := merged.ParentNode(); parent != nil {
+		index := nodeIndex(merged)
+
+		if r.startContainer.IsSameNode(parent) && r.startOffset == index {
+			r.startContainer, r.startOffset = node, length
+		}
+		if r.endContainer.IsSameNode(parent) && r.endOffset == index {
+			r.endContainer, r.endOffset = node, length
+		}
+	}
+}
+
+/*****************************************************
+ **************** Getters & Setters ******************
+ *****************************************************/
+// ANCHOR Getters & Setters
+
+// Collapsed report whether the start and end boundary
+// points of the range are at the same position.
+// https://developer.mozilla.org/en-US/docs/Web/API/AbstractRange/collapsed
+func (r *abstractRange) Collapsed() bool {
+	return r.startContainer.IsSameNode(r.endContainer) && r.startOffset == r.endOffset
+}
+
+// EndContainer return the node within which the range ends.
+// https://developer.mozilla.org/en-US/docs/Web/API/AbstractRange/endContainer
+func (r *abstractRange) EndContainer() Node {
+	return r.endContainer
+}
+
+// EndOffset return the offset of the range end within
+// the end container.
+// https://developer.mozilla.org/en-US/docs/Web/API/AbstractRange/endOffset
+func (r *abstractRange) EndOffset() int {
+	return r.endOffset
+}
+
+// StartContainer return the node within which the range
+// starts.
+// https://developer.mozilla.org/en-US/docs/Web/API/AbstractRange/startContainer
+func (r *abstractRange) StartContainer() Node {
+	return r.startContainer
+}
+
+// StartOffset return the offset of the range start within
+// the start container.
+// https://developer.mozilla.org/en-US/docs/Web/API/AbstractRange/startOffset
+func (r *abstractRange) StartOffset() int {
+	return r.startOffset
+}
+
+// CommonAncestorContainer return the deepest node that
+// contains both boundary points of the range.
+// https://developer.mozilla.org/en-US/docs/Web/API/Range/commonAncestorContainer
+func (r *liveRange) CommonAncestorContainer() Node {
+	return r.commonAncestorContainer()
+}
+
+/*****************************************************
+ ********************* Methods ***********************
+ *****************************************************/
+// ANCHOR Methods
+
+// CloneContents return a DocumentFragment containing a
+// copy of the content of the range.
+// https://developer.mozilla.org/en-US/docs/Web/API/Range/cloneContents
+// https://dom.spec.whatwg.org/#concept-range-clone
+func (r *liveRange) CloneContents() (DocumentFragment, e.Exception) {
+	return r.processContents(false)
+}
+
+// CloneRange return a new Range with the same boundary
+// points. The clone must be released like the ranges
+// created by Document.CreateRange.
+// https://developer.mozilla.org/en-US/docs/Web/API/Range/cloneRange
+func (r *liveRange) CloneRange() Range {
+	clone := newUnregisteredRange(r.startContainer, r.startOffset, r.endContainer, r.endOffset)
+	clone.released = false
+	clone.register(nodeDocument(r.startContainer))
+
+	return clone
+}
+
+// Collapse the range to its start if toStart is true or
+// to its end otherwise.
+// https://developer.mozilla.org/en-US/docs/Web/API/Range/collapse
+func (r *liveRange) Collapse(toStart bool) {
+	if toStart {
+		r.setStartAndEnd(r.startContainer, r.startOffset)
+	} else {
+		r.setStartAndEnd(r.endContainer, r.endOffset)
+	}
+}
+
+// CompareBoundaryPoints compare a boundary point of the range
+// with one of the source range according to how. It return
+// -1, 0 or 1 if the range point is before, equal or after
+// the source point.
+// https://developer.mozilla.org/en-US/docs/Web/API/Range/compareBoundaryPoints
+func (r *liveRange) CompareBoundaryPoints(how int, source Range) (int, e.Exception) {
+	if source == nil {
+		return 0, e.TypeError("The source range is nil.")
+	}
+
+	var thisNode, sourceNode Node
+	var thisOffset, sourceOffset int
+
+	switch how {
+	case RangeStartToStart:
+		thisNode, thisOffset = r.startContainer, r.startOffset
+		sourceNode, sourceOffset = source.StartContainer(), source.StartOffset()
+	case RangeStartToEnd:
+		thisNode, thisOffset = r.endContainer, r.endOffset
+		sourceNode, sourceOffset = source.StartContainer(), source.StartOffset()
+	case RangeEndToEnd:
+		thisNode, thisOffset = r.endContainer, r.endOffset
+		sourceNode, sourceOffset = source.EndContainer(), source.EndOffset()
+	case RangeEndToStart:
+		thisNode, thisOffset = r.startContainer, r.startOffset
+		sourceNode, sourceOffset = source.EndContainer(), source.EndOffset()
+	default:
+		return 0, e.New(e.NotSupportedError, "%v is not a valid comparison method.", how)
+	}
+
+	if !r.root().IsSameNode(source.StartContainer().GetRootNode()) {
+		return 0, e.New(e.WrongDocumentError, "The ranges have different roots.")
+	}
+
+	return comparePoints(thisNode, thisOffset, sourceNode, sourceOffset), nil
+}
+
+// ComparePoint return -1, 0 or 1 if the boundary point is
+// before, inside or after the range.
+// https://developer.mozilla.org/en-US/docs/Web/API/Range/comparePoint
+func (r *liveRange) ComparePoint(node Node, offset int) (int, e.Exception) {
+	if node == nil || !node.GetRootNode().IsSameNode(r.root()) {
+		return 0, e.New(e.WrongDocumentError, "The node and the range have different roots.")
+	}
+
+	if err := checkPoint(node, offset); err != nil {
+		return 0, err
+	}
+
+	switch {
+	case comparePoints(node, offset, r.startContainer, r.startOffset) < 0:
+		return -1, nil
+	case comparePoints(node, offset, r.endContainer, r.endOffset) > 0:
+		return 1, nil
+	}
+
+	return 0, nil
+}
+
+// DeleteContents removes the contents of the range.
+// https://developer.mozilla.org/en-US/docs/Web/API/Range/deleteContents
+// https://dom.spec.whatwg.org/#dom-range-deletecontents
+func (r *liveRange) DeleteContents() e.Exception {
+	if r.Collapsed() {
+		return nil
+	}
+
+	startNode, startOffset := r.startContainer, r.startOffset
+	endNode, endOffset := r.endContainer, r.endOffset
+
+	if startNode.IsSameNode(endNode) && isCharacterData(startNode) {
+		startNode.(CharacterData).ReplaceData(uint(startOffset), uint(endOffset-startOffset), "")
+		return nil
+	}
+
+	nodesToRemove := r.containedNodes()
+	newNode, newOffset := r.collapsePoint()
+
+	if isCharacterData(startNode) {
+		startNode.(CharacterData).ReplaceData(uint(startOffset), uint(nodeLength(startNode)-startOffset), "")
+	}
+
+	for _, node := range nodesToRemove {
+		node.ParentNode().RemoveChild(node)
+	}
+
+	if isCharacterData(endNode) {
+		endNode.(CharacterData).ReplaceData(0, uint(endOffset), "")
+	}
+
+	r.setStartAndEnd(newNode, newOffset)
+
+	return nil
+}
+
+// collapsePoint return the boundary point where the range
+// is collapsed once its contents are removed.
+func (r *liveRange) collapsePoint() (Node, int) {
+	if isInclusiveAncestor(r.startContainer, r.endContainer) {
+		return r.startContainer, r.startOffset
+	}
+
+	reference := r.startContainer
+	for !isInclusiveAncestor(reference.ParentNode(), r.endContainer) {
+		reference = reference.ParentNode()
+	}
+
+	return reference.ParentNode(), nodeIndex(reference) + 1
+}
+
+// Detach does nothing, as specified. Use Release to stop
+// updating the range.
+// https://developer.mozilla.org/en-US/docs/Web/API/Range/detach
+// https://dom.spec.whatwg.org/#dom-range-detach
+func (r *liveRange) Detach() {}
+
+// ExtractContents moves the contents of the range to a
+// DocumentFragment and returns it.
+// https://developer.mozilla.org/en-US/docs/Web/API/Range/extractContents
+// https://dom.spec.whatwg.org/#concept-range-extract
+func (r *liveRange) ExtractContents() (DocumentFragment, e.Exception) {
+	return r.processContents(true)
+}
+
+// processContents extract the contents of the range or
+// clone it if extract is false.
+func (r *liveRange) processContents(extract bool) (DocumentFragment, e.Exception) {
+	fragment := createDocumentFragment()
+	fragment.SetOwnerDocument(nodeDocument(r.startContainer))
+
+	if r.Collapsed() {
+		return fragment, nil
+	}
+
+	startNode, startOffset := r.startContainer, r.startOffset
+	endNode, endOffset := r.endContainer, r.endOffset
+
+	// cloneData append a clone of the character data node to
+	// the fragment with count characters starting at offset.
+	cloneData := func(node Node, offset, count int) {
+		clone := node.CloneNode(false).(CharacterData)
+		clone.SetData(node.(CharacterData).SubstringData(uint(offset), uint(count)))
+		fragment.AppendChild(clone)
+
+		if extract {
+			node.(CharacterData).ReplaceData(uint(offset), uint(count), "")
+		}
+	}
+
+	if startNode.IsSameNode(endNode) && isCharacterData(startNode) {
+		cloneData(startNode, startOffset, endOffset-startOffset)
+		return fragment, nil
+	}
+
+	commonAncestor := r.commonAncestorContainer()
+
+	var firstPartiallyContained, lastPartiallyContained Node
+	var containedChildren []Node
+	for child := commonAncestor.FirstChild(); child != nil; child = child.NextSibling() {
+		switch {
+		case r.contains(child):
+			if child.NodeType() == DocumentTypeNode {
+				return nil, e.New(e.HierarchyRequestError, "A DocumentType node can't be moved to a DocumentFragment.")
+			}
+			containedChildren = append(containedChildren, child)
+
+		case r.partiallyContains(child):
+			if firstPartiallyContained == nil && !isInclusiveAncestor(startNode, endNode) {
+				firstPartiallyContained = child
+			} else if !isInclusiveAncestor(endNode, startNode) {
+				lastPartiallyContained = child
+			}
+		}
+	}
+
+	var newNode Node
+	var newOffset int
+	if extract {
+		newNode, newOffset = r.collapsePoint()
+	}
+
+	// processSubrange append a clone of the partially contained
+	// child and the contents of the subrange to the fragment.
+	processSubrange := func(child, startNode Node, startOffset int, endNode Node, endOffset int) e.Exception {
+		clone := child.CloneNode(false)
+		fragment.AppendChild(clone)
+
+		subrange := newUnregisteredRange(startNode, startOffset, endNode, endOffset)
+		subfragment, err := subrange.processContents(extract)
+		if err != nil {
+			return err
+		}
+
+		_, err = clone.AppendChild(subfragment)
+		return err
+	}
+
+	if firstPartiallyContained != nil {
+		if isCharacterData(firstPartiallyContained) {
+			cloneData(startNode, startOffset, nodeLength(startNode)-startOffset)
+		} else {
+			err := processSubrange(firstPartiallyContained,
+				startNode, startOffset, firstPartiallyContained, nodeLength(firstPartiallyContained))
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	for _, child := range containedChildren {
+		if !extract {
+			child = child.CloneNode(true)
+		}
+		fragment.AppendChild(child)
+	}
+
+	if lastPartiallyContained != nil {
+		if isCharacterData(lastPartiallyContained) {
+			cloneData(endNode, 0, endOffset)
+		} else {
+			err := processSubrange(lastPartiallyContained,
+				lastPartiallyContained, 0, endNode, endOffset)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if extract {
+		r.setStartAndEnd(newNode, newOffset)
+	}
+
+	return fragment, nil
+}
+
+// InsertNode inserts the node at the start of the range.
+// https://developer.mozilla.org/en-US/docs/Web/API/Range/insertNode
+// https://dom.spec.whatwg.org/#concept-range-insert
+func (r *liveRange) InsertNode(node Node) e.Exception {
+	if node == nil {
+		return e.TypeError("The node is nil.")
+	}
+
+	startNode := r.startContainer
+
+	if startNode.NodeType() == CommentNode ||
+		(startNode.NodeType() == TextNode && startNode.ParentNode() == nil) ||
+		startNode.IsSameNode(node) {
+		return e.New(e.HierarchyRequestError, "The node can't be inserted at the start of the range.")
+	}
+
+	var reference Node
+	if startNode.NodeType() == TextNode {
+		reference = startNode
+	} else {
+		reference = startNode.ChildNodes().Item(r.startOffset)
+	}
+
+	parent := startNode
+	if reference != nil {
+		parent = reference.ParentNode()
+	}
+
+	if err := ensurePreInsertionValidity(parent, node, reference); err != nil {
+		return err
+	}
+
+	if startNode.NodeType() == TextNode {
+		var err e.Exception
+		if reference, err = startNode.(Text).SplitText(uint(r.startOffset)); err != nil {
+			return err
+		}
+	}
+
+	if reference != nil && node.IsSameNode(reference) {
+		reference = reference.NextSibling()
+	}
+
+	detach(node)
+
+	newOffset := nodeLength(parent)
+	if reference != nil {
+		newOffset = nodeIndex(reference)
+	}
+	if node.NodeType() == DocumentFragmentNode {
+		newOffset += nodeLength(node)
+	} else {
+		newOffset++
+	}
+
+	if _, err := parent.InsertBefore(node, reference); err != nil {
+		return err
+	}
+
+	if r.Collapsed() {
+		r.endContainer, r.endOffset = parent, newOffset
+	}
+
+	return nil
+}
+
+// IntersectsNode report whether the node intersects the
+// range.
+// https://developer.mozilla.org/en-US/docs/Web/API/Range/intersectsNode
+func (r *liveRange) IntersectsNode(node Node) bool {
+	if node == nil || !node.GetRootNode().IsSameNode(r.root()) {
+		return false
+	}
+
+	parent := node.ParentNode()
+	if parent == nil {
+		return true
+	}
+
+	offset := nodeIndex(node)
+
+	return comparePoints(parent, offset, r.endContainer, r.endOffset) < 0 &&
+		comparePoints(parent, offset+1, r.startContainer, r.startOffset) > 0
+}
+
+// IsPointInRange report whether the boundary point is
+// inside the range.
+// https://developer.mozilla.org/en-US/docs/Web/API/Range/isPointInRange
+func (r *liveRange) IsPointInRange(node Node, offset int) (bool, e.Exception) {
+	if node == nil || !node.GetRootNode().IsSameNode(r.root()) {
+		return false, nil
+	}
+
+	position, err := r.ComparePoint(node, offset)
+
+	return err == nil && position == 0, err
+}
+
+// Release unregister the range from its document. The
+// range is no longer updated when the tree is modified
+// and the document no longer keeps a reference to it.
+// A released range can't be registered again.
+func (r *liveRange) Release() {
+	r.register(nil)
+	r.released = true
+}
+
+// SelectNode sets the range to contain the node and its
+// contents.
+// https://developer.mozilla.org/en-US/docs/Web/API/Range/selectNode
+func (r *liveRange) SelectNode(node Node) e.Exception {
+	if node == nil {
+		return e.TypeError("The node is nil.")
+	}
+
+	parent := node.ParentNode()
+	if parent == nil {
+		return e.New(e.InvalidNodeTypeError, "The node has no parent.")
+	}
+
+	index := nodeIndex(node)
+	r.startContainer, r.startOffset = parent, index
+	r.endContainer, r.endOffset = parent, index+1
+	r.register(nodeDocument(parent))
+
+	return nil
+}
+
+// SelectNodeContents sets the range to contain the
+// contents of the node.
+// https://developer.mozilla.org/en-US/docs/Web/API/Range/selectNodeContents
+func (r *liveRange) SelectNodeContents(node Node) e.Exception {
+	if node == nil {
+		return e.TypeError("The node is nil.")
+	}
+
+	if node.NodeType() == DocumentTypeNode {
+		return e.New(e.InvalidNodeTypeError, "A range can't select the contents of a DocumentType node.")
+	}
+
+	r.startContainer, r.startOffset = node, 0
+	r.endContainer, r.endOffset = node, nodeLength(node)
+	r.register(nodeDocument(node))
+
+	return nil
+}
+
+// SetEnd sets the end boundary point of the range.
+// https://developer.mozilla.org/en-US/docs/Web/API/Range/setEnd
+func (r *liveRange) SetEnd(node Node, offset int) e.Exception {
+	return r.setBoundary(node, offset, false)
+}
+
+// SetEndAfter sets the end boundary point of the range
+// after the node.
+// https://developer.mozilla.org/en-US/docs/Web/API/Range/setEndAfter
+func (r *liveRange) SetEndAfter(node Node) e.Exception {
+	return r.setBoundaryBeside(node, true, false)
+}
+
+// SetEndBefore sets the end boundary point of the range
+// before the node.
+// https://developer.mozilla.org/en-US/docs/Web/API/Range/setEndBefore
+func (r *liveRange) SetEndBefore(node Node) e.Exception {
+	return r.setBoundaryBeside(node, false, false)
+}
+
+// SetStart sets the start boundary point of the range.
+// https://developer.mozilla.org/en-US/docs/Web/API/Range/setStart
+func (r *liveRange) SetStart(node Node, offset int) e.Exception {
+	return r.setBoundary(node, offset, true)
+}
+
+// SetStartAfter sets the start boundary point of the range
+// after the node.
+// https://developer.mozilla.org/en-US/docs/Web/API/Range/setStartAfter
+func (r *liveRange) SetStartAfter(node Node) e.Exception {
+	return r.setBoundaryBeside(node, true, true)
+}
+
+// SetStartBefore sets the start boundary point of the range
+// before the node.
+// https://developer.mozilla.org/en-US/docs/Web/API/Range/setStartBefore
+func (r *liveRange) SetStartBefore(node Node) e.Exception {
+	return r.setBoundaryBeside(node, false, true)
+}
+
+// SurroundContents moves the contents of the range into
+// newParent and inserts newParent in place of the contents.
+// https://developer.mozilla.org/en-US/docs/Web/API/Range/surroundContents
+func (r *liveRange) SurroundContents(newParent Node) e.Exception {
+	if newParent == nil {
+		return e.TypeError("The new parent node is nil.")
+	}
+
+	for _, container := range []Node{r.startContainer, r.endContainer} {
+		for node := container; node != nil; node = node.ParentNode() {
+			if node.NodeType() != TextNode && r.partiallyContains(node) {
+				return e.New(e.InvalidStateError, "The range partially contains a non-Text node.")
+			}
+		}
+	}
+
+	switch newParent.NodeType() {
+	case DocumentNode, DocumentTypeNode, DocumentFragmentNode:
+		return e.New(e.InvalidNodeTypeError, "A %v node can't surround the range contents.", newParent.NodeName())
+	}
+
+	fragment, err := r.ExtractContents()
+	if err != nil {
+		return err
+	}
+
+	for child := newParent.LastChild(); child != nil; child = newParent.LastChild() {
+		newParent.RemoveChild(child)
+	}
+
+	if err := r.InsertNode(newParent); err != nil {
+		return err
+	}
+
+	if _, err := newParent.AppendChild(fragment); err != nil {
+		return err
+	}
+
+	return r.SelectNode(newParent)
+}
+
+// ToString return the text of the range.
+// https://developer.mozilla.org/en-US/docs/Web/API/Range/toString
+func (r *liveRange) ToString() string {
+	startNode, endNode := r.startContainer, r.endContainer
+
+	if startNode.IsSameNode(endNode) && startNode.NodeType() == TextNode {
+		return startNode.(Text).SubstringData(uint(r.startOffset), uint(r.endOffset-r.startOffset))
+	}
+
+	var b strings.Builder
+	if startNode.NodeType() == TextNode {
+		b.WriteString(startNode.(Text).SubstringData(uint(r.startOffset), uint(nodeLength(startNode))))
+	}
+
+	for _, node := range r.containedNodes() {
+		switch node.NodeType() {
+		case TextNode:
+			b.WriteString(node.(Text).Data())
+		case CommentNode:
+		default:
+			b.WriteString(node.TextContent())
+		}
+	}
+
+	if endNode.NodeType() == TextNode {
+		b.WriteString(endNode.(Text).SubstringData(0, uint(r.endOffset)))
+	}
+
+	return b.String()
+}
@@ -0,0 +1,505 @@
+package gom
+
+import (
+	"testing"
+
+	e "github.com/negrel/gom/exception"
+)
+
+const rangeTestSrc = `<div><p>Hello <b>big</b> world</p><ul><li>one</li><li>two</li></ul></div>`
+
+// fragmentGOML return the markup of the fragment children.
+func fragmentGOML(doc Document, fragment DocumentFragment) string {
+	container := doc.CreateElement("div")
+	container.AppendChild(fragment)
+
+	return container.InnerGOML()
+}
+
+// rangeTestNodes return the document and the p, "Hello ",
+// " world" and ul nodes of rangeTestSrc.
+func rangeTestNodes(t *testing.T) (Document, Element, Text, Text, Element) {
+	doc := parseTestDocument(t, rangeTestSrc)
+	div := doc.DocumentElement()
+	p := div.FirstElementChild()
+
+	return doc, p, p.FirstChild().(Text), p.LastChild().(Text), div.LastElementChild()
+}
+
+func TestNewStaticRange(t *testing.T) {
+	_, p, hello, _, _ := rangeTestNodes(t)
+
+	r, err := NewStaticRange(hello, 1, p, 2)
+	if err != nil {
+		t.Fatalf("Error while creating a StaticRange : %v", err)
+	}
+
+	if !r.StartContainer().IsSameNode(hello) || r.StartOffset() != 1 ||
+		!r.EndContainer().IsSameNode(p) || r.EndOffset() != 2 || r.Collapsed() {
+		t.Log("StaticRange boundary points must be the given ones.")
+		t.Fail()
+	}
+
+	// StaticRange are not updated
+	hello.SetData("")
+	if r.StartOffset() != 1 {
+		t.Log("StaticRange must not be updated when the tree is modified.")
+		t.Fail()
+	}
+
+	_, err = NewStaticRange(newDocumentType("goml"), 0, p, 0)
+	if err == nil || err.Name() != e.New(e.InvalidNodeTypeError, "").Name() {
+		t.Logf("A DocumentType container must return an InvalidNodeTypeError, got %v.", err)
+		t.Fail()
+	}
+}
+
+func TestRangeSetStartSetEnd(t *testing.T) {
+	doc, p, hello, world, ul := rangeTestNodes(t)
+	r := doc.CreateRange()
+
+	if !r.StartContainer().IsSameNode(doc) || !r.Collapsed() {
+		t.Log("CreateRange must return a range collapsed at the start of the document.")
+		t.Fail()
+	}
+
+	if err := r.SetStart(hello, 2); err != nil {
+		t.Fatalf("Error while setting the range start : %v", err)
+	}
+	// Start after the end collapse the range
+	if !r.EndContainer().IsSameNode(hello) || r.EndOffset() != 2 {
+		t.Log("Setting the start after the end must collapse the range.")
+		t.Fail()
+	}
+
+	if err := r.SetEnd(world, 3); err != nil {
+		t.Fatalf("Error while setting the range end : %v", err)
+	}
+	if r.Collapsed() || !r.CommonAncestorContainer().IsSameNode(p) {
+		t.Logf("The common ancestor must be the p element, got %v.", nodeNames(r.CommonAncestorContainer()))
+		t.Fail()
+	}
+
+	if err := r.SetEnd(world, 42); err == nil || err.Name() != "RangeError" {
+		t.Logf("An offset larger than the node length must return a RangeError, got %v.", err)
+		t.Fail()
+	}
+
+	if err := r.SetStart(newDocumentType("goml"), 0); err == nil {
+		t.Log("A DocumentType boundary point must return an error.")
+		t.Fail()
+	}
+
+	if err := r.SetEndAfter(ul); err != nil || !r.EndContainer().IsSameNode(ul.ParentNode()) || r.EndOffset() != 2 {
+		t.Logf("SetEndAfter must set the end after the node, got (%v, %v).", nodeNames(r.EndContainer()), r.EndOffset())
+		t.Fail()
+	}
+
+	if err := r.SetStartBefore(ul); err != nil || !r.StartContainer().IsSameNode(ul.ParentNode()) || r.StartOffset() != 1 {
+		t.Logf("SetStartBefore must set the start before the node, got (%v, %v).", nodeNames(r.StartContainer()), r.StartOffset())
+		t.Fail()
+	}
+
+	if err := r.SetStartAfter(doc); err == nil {
+		t.Log("SetStartAfter must return an error if the node has no parent.")
+		t.Fail()
+	}
+}
+
+func TestRangeSelectAndCollapse(t *testing.T) {
+	doc, p, _, _, ul := rangeTestNodes(t)
+	r := doc.CreateRange()
+
+	if err := r.SelectNode(ul); err != nil {
+		t.Fatalf("Error while selecting the node : %v", err)
+	}
+	if !r.StartContainer().IsSameNode(doc.DocumentElement()) || r.StartOffset() != 1 || r.EndOffset() != 2 {
+		t.Logf("SelectNode must select the node in its parent, got (%v, %v).", r.StartOffset(), r.EndOffset())
+		t.Fail()
+	}
+
+	if err := r.SelectNodeContents(p); err != nil {
+		t.Fatalf("Error while selecting the node contents : %v", err)
+	}
+	if !r.StartContainer().IsSameNode(p) || r.StartOffset() != 0 || r.EndOffset() != 3 {
+		t.Logf("SelectNodeContents must select the node children, got (%v, %v).", r.StartOffset(), r.EndOffset())
+		t.Fail()
+	}
+
+	r.Collapse(false)
+	if !r.Collapsed() || r.StartOffset() != 3 {
+		t.Log("Collapse(false) must collapse the range to its end.")
+		t.Fail()
+	}
+
+	if err := r.SelectNodeContents(newDocumentType("goml")); err == nil {
+		t.Log("SelectNodeContents must return an error for a DocumentType node.")
+		t.Fail()
+	}
+}
+
+func TestRangeNilArguments(t *testing.T) {
+	doc := parseTestDocument(t, rangeTestSrc)
+	r := doc.CreateRange()
+	defer r.Release()
+
+	tests := []struct {
+		name string
+		call func() e.Exception
+	}{
+		{"SelectNode", func() e.Exception { return r.SelectNode(nil) }},
+		{"SelectNodeContents", func() e.Exception { return r.SelectNodeContents(nil) }},
+		{"SetStart", func() e.Exception { return r.SetStart(nil, 0) }},
+		{"SetEnd", func() e.Exception { return r.SetEnd(nil, 0) }},
+		{"SetStartBefore", func() e.Exception { return r.SetStartBefore(nil) }},
+		{"SetStartAfter", func() e.Exception { return r.SetStartAfter(nil) }},
+		{"SetEndBefore", func() e.Exception { return r.SetEndBefore(nil) }},
+		{"SetEndAfter", func() e.Exception { return r.SetEndAfter(nil) }},
+		{"InsertNode", func() e.Exception { return r.InsertNode(nil) }},
+		{"SurroundContents", func() e.Exception { return r.SurroundContents(nil) }},
+		{"CompareBoundaryPoints", func() e.Exception {
+			_, err := r.CompareBoundaryPoints(RangeStartToStart, nil)
+			return err
+		}},
+	}
+
+	for _, test := range tests {
+		if err := test.call(); err == nil || err.Name() != "TypeError" {
+			t.Logf("%v must return a TypeError for a nil node, got %v.", test.name, err)
+			t.Fail()
+		}
+	}
+}
+
+func TestRangeCompare(t *testing.T) {
+	doc, p, hello, world, ul := rangeTestNodes(t)
+
+	a := doc.CreateRange()
+	a.SelectNodeContents(p)
+	b := doc.CreateRange()
+	b.SelectNode(ul)
+
+	comparisons := []struct {
+		how, expected int
+	}{
+		{RangeStartToStart, -1},
+		{RangeStartToEnd, -1},
+		{RangeEndToEnd, -1},
+		{RangeEndToStart, -1},
+	}
+	for _, c := range comparisons {
+		if result, err := a.CompareBoundaryPoints(c.how, b); err != nil || result != c.expected {
+			t.Logf("CompareBoundaryPoints(%v) must return %v, got %v (%v).", c.how, c.expected, result, err)
+			t.Fail()
+		}
+	}
+
+	if result, _ := b.CompareBoundaryPoints(RangeStartToEnd, a); result != 1 {
+		t.Logf("The start of b must be after the end of a, got %v.", result)
+		t.Fail()
+	}
+
+	if _, err := a.CompareBoundaryPoints(42, b); err == nil {
+		t.Log("An invalid comparison method must return an error.")
+		t.Fail()
+	}
+
+	other := parseTestDocument(t, rangeTestSrc).CreateRange()
+	if _, err := a.CompareBoundaryPoints(RangeStartToStart, other); err == nil {
+		t.Log("Comparing ranges of different documents must return an error.")
+		t.Fail()
+	}
+
+	if position, _ := a.ComparePoint(world, 2); position != 0 {
+		t.Logf("A point in the range must be compared as 0, got %v.", position)
+		t.Fail()
+	}
+	if position, _ := a.ComparePoint(ul, 0); position != 1 {
+		t.Logf("A point after the range must be compared as 1, got %v.", position)
+		t.Fail()
+	}
+
+	if in, _ := a.IsPointInRange(hello, 0); !in {
+		t.Log("The start of the first text must be in the range.")
+		t.Fail()
+	}
+
+	if !a.IntersectsNode(hello) || !a.IntersectsNode(p) || a.IntersectsNode(ul) {
+		t.Log("IntersectsNode must report the nodes intersecting the range.")
+		t.Fail()
+	}
+}
+
+func TestRangeToString(t *testing.T) {
+	doc, _, hello, world, _ := rangeTestNodes(t)
+	r := doc.CreateRange()
+
+	r.SetStart(hello, 2)
+	r.SetEnd(world, 3)
+	if s := r.ToString(); s != "llo big wo" {
+		t.Logf("ToString must return the text of the range, got %q.", s)
+		t.Fail()
+	}
+
+	r.SetEnd(hello, 4)
+	if s := r.ToString(); s != "ll" {
+		t.Logf("ToString must return a substring of the text, got %q.", s)
+		t.Fail()
+	}
+}
+
+func TestRangeCloneContents(t *testing.T) {
+	doc, _, hello, _, ul := rangeTestNodes(t)
+	r := doc.CreateRange()
+	r.SetStart(hello, 2)
+	r.SetEnd(ul.FirstChild().FirstChild(), 2)
+
+	fragment, err := r.CloneContents()
+	if err != nil {
+		t.Fatalf("Error while cloning the range contents : %v", err)
+	}
+
+	expected := `<p>llo <b>big</b> world</p><ul><li>on</li></ul>`
+	if markup := fragmentGOML(doc, fragment); markup != expected {
+		t.Logf("The fragment must contain a copy of the range contents, got %q.", markup)
+		t.Fail()
+	}
+
+	if markup := doc.DocumentElement().OuterGOML(); markup != `<div><p>Hello <b>big</b> world</p><ul><li>one</li><li>two</li></ul></div>` {
+		t.Logf("CloneContents must not modify the document, got %q.", markup)
+		t.Fail()
+	}
+}
+
+func TestRangeExtractContents(t *testing.T) {
+	doc, _, hello, _, ul := rangeTestNodes(t)
+	r := doc.CreateRange()
+	r.SetStart(hello, 2)
+	r.SetEnd(ul.FirstChild().FirstChild(), 2)
+
+	fragment, err := r.ExtractContents()
+	if err != nil {
+		t.Fatalf("Error while extracting the range contents : %v", err)
+	}
+
+	expected := `<p>llo <b>big</b> world</p><ul><li>on</li></ul>`
+	if markup := fragmentGOML(doc, fragment); markup != expected {
+		t.Logf("The fragment must contain the range contents, got %q.", markup)
+		t.Fail()
+	}
+
+	if markup := doc.DocumentElement().OuterGOML(); markup != `<div><p>He</p><ul><li>e</li><li>two</li></ul></div>` {
+		t.Logf("ExtractContents must remove the range contents, got %q.", markup)
+		t.Fail()
+	}
+
+	if !r.Collapsed() || !r.StartContainer().IsSameNode(doc.DocumentElement()) || r.StartOffset() != 1 {
+		t.Logf("The range must be collapsed between the partially contained nodes, got (%v, %v).",
+			nodeNames(r.StartContainer()), r.StartOffset())
+		t.Fail()
+	}
+}
+
+func TestRangeDeleteContents(t *testing.T) {
+	doc, p, hello, world, _ := rangeTestNodes(t)
+	r := doc.CreateRange()
+	r.SetStart(hello, 4)
+	r.SetEnd(world, 3)
+
+	if err := r.DeleteContents(); err != nil {
+		t.Fatalf("Error while deleting the range contents : %v", err)
+	}
+
+	if markup := p.OuterGOML(); markup != `<p>Hellrld</p>` {
+		t.Logf("DeleteContents must remove the range contents, got %q.", markup)
+		t.Fail()
+	}
+
+	if !r.Collapsed() || !r.StartContainer().IsSameNode(p) || r.StartOffset() != 1 {
+		t.Logf("The range must be collapsed after the start node, got (%v, %v).",
+			nodeNames(r.StartContainer()), r.StartOffset())
+		t.Fail()
+	}
+}
+
+func TestRangeInsertNode(t *testing.T) {
+	doc, p, hello, _, _ := rangeTestNodes(t)
+	r := doc.CreateRange()
+	r.SetStart(hello, 2)
+
+	if err := r.InsertNode(doc.CreateElement("i")); err != nil {
+		t.Fatalf("Error while inserting the node : %v", err)
+	}
+
+	if markup := p.OuterGOML(); markup != `<p>He<i></i>llo <b>big</b> world</p>` {
+		t.Logf("InsertNode must split the text and insert the node, got %q.", markup)
+		t.Fail()
+	}
+
+	if !r.StartContainer().IsSameNode(hello) || !r.EndContainer().IsSameNode(p) || r.EndOffset() != 2 {
+		t.Logf("A collapsed range must contain the inserted node, got end (%v, %v).", nodeNames(r.EndContainer()), r.EndOffset())
+		t.Fail()
+	}
+
+	comment := doc.CreateComment("comment")
+	p.AppendChild(comment)
+	r.SetStart(comment, 0)
+	if err := r.InsertNode(doc.CreateElement("i")); err == nil {
+		t.Log("Inserting a node in a comment must return an error.")
+		t.Fail()
+	}
+}
+
+func TestRangeSurroundContents(t *testing.T) {
+	doc, p, hello, world, _ := rangeTestNodes(t)
+	r := doc.CreateRange()
+	r.SetStart(p.FirstElementChild().FirstChild(), 1)
+	r.SetEnd(world, 3)
+
+	if err := r.SurroundContents(doc.CreateElement("i")); err == nil || err.Name() != "InvalidStateError" {
+		t.Logf("Partially contained non-Text nodes must return an InvalidStateError, got %v.", err)
+		t.Fail()
+	}
+
+	r.SetStart(hello, 2)
+	r.SetEnd(hello, 4)
+	if err := r.SurroundContents(doc.CreateElement("i")); err != nil {
+		t.Fatalf("Error while surrounding the range contents : %v", err)
+	}
+
+	if markup := p.OuterGOML(); markup != `<p>He<i>ll</i>o <b>big</b> world</p>` {
+		t.Logf("SurroundContents must move the contents in the new parent, got %q.", markup)
+		t.Fail()
+	}
+
+	if !r.StartContainer().IsSameNode(p) || r.StartOffset() != 1 || r.EndOffset() != 2 {
+		t.Logf("The range must select the new parent, got (%v, %v).", r.StartOffset(), r.EndOffset())
+		t.Fail()
+	}
+}
+
+func TestRangeLiveUpdates(t *testing.T) {
+	doc, p, hello, world, ul := rangeTestNodes(t)
+	div := doc.DocumentElement()
+	r := doc.CreateRange()
+	r.SetStart(hello, 3)
+	r.SetEnd(div, 2)
+
+	// Insertion before the end
+	div.InsertBefore(doc.CreateElement("hr"), ul)
+	if r.EndOffset() != 3 {
+		t.Logf("Inserting a node before the end must increment its offset, got %v.", r.EndOffset())
+		t.Fail()
+	}
+
+	// Data replacement
+	hello.InsertData(0, "Oh ")
+	if r.StartOffset() != 6 {
+		t.Logf("Inserting data before the start must increment its offset, got %v.", r.StartOffset())
+		t.Fail()
+	}
+	hello.DeleteData(1, 7)
+	if r.StartOffset() != 1 {
+		t.Logf("Deleting the data containing the start must move it to the offset, got %v.", r.StartOffset())
+		t.Fail()
+	}
+
+	// Split
+	hello.SetData("Hello ")
+	r.SetStart(hello, 4)
+	second, _ := hello.SplitText(2)
+	if !r.StartContainer().IsSameNode(second) || r.StartOffset() != 2 {
+		t.Logf("Splitting the start text must move the start in the new node, got (%v, %v).",
+			nodeNames(r.StartContainer()), r.StartOffset())
+		t.Fail()
+	}
+
+	// Normalize
+	p.Normalize()
+	if !r.StartContainer().IsSameNode(hello) || r.StartOffset() != 4 {
+		t.Logf("Normalize must move the start in the merged text, got (%v, %v).",
+			nodeNames(r.StartContainer()), r.StartOffset())
+		t.Fail()
+	}
+
+	// Removal of the start ancestor
+	div.RemoveChild(p)
+	if !r.StartContainer().IsSameNode(div) || r.StartOffset() != 0 || r.EndOffset() != 2 {
+		t.Logf("Removing the start ancestor must move the start in the parent, got (%v, %v) - (%v, %v).",
+			nodeNames(r.StartContainer()), r.StartOffset(), nodeNames(r.EndContainer()), r.EndOffset())
+		t.Fail()
+	}
+
+	// Detach does nothing
+	r.Detach()
+	div.InsertBefore(doc.CreateElement("hr"), div.FirstChild())
+	if r.EndOffset() != 3 {
+		t.Log("Detached ranges must still be updated.")
+		t.Fail()
+	}
+
+	// Released ranges are not updated
+	r.Release()
+	div.AppendChild(world)
+	div.InsertBefore(doc.CreateElement("hr"), div.FirstChild())
+	if r.EndOffset() != 3 {
+		t.Log("Released ranges must not be updated.")
+		t.Fail()
+	}
+
+	if _, registered := doc.liveRanges()[r.(*liveRange)]; registered {
+		t.Log("Released ranges must be removed from their document.")
+		t.Fail()
+	}
+
+	// Released ranges are not registered again
+	r.SetStart(div, 0)
+	if len(doc.liveRanges()) != 0 {
+		t.Log("Released ranges must not be registered again.")
+		t.Fail()
+	}
+}
+
+func TestRangeDetachedSplit(t *testing.T) {
+	doc := newDocument()
+	text := doc.CreateTextNode("Hello")
+	r := doc.CreateRange()
+	r.SetStart(text, 4)
+	r.SetEnd(text, 5)
+
+	second, _ := text.SplitText(2)
+	if !r.StartContainer().IsSameNode(text) || r.StartOffset() != 2 ||
+		!r.EndContainer().IsSameNode(text) || r.EndOffset() != 2 {
+		t.Logf("Splitting a text without parent must only truncate the boundaries, got (%v, %v) - (%v, %v).",
+			nodeNames(r.StartContainer()), r.StartOffset(), nodeNames(r.EndContainer()), r.EndOffset())
+		t.Fail()
+	}
+
+	if second.Data() != "llo" || second.ParentNode() != nil {
+		t.Log("The new text node must contain the data after the offset and have no parent.")
+		t.Fail()
+	}
+}
+
+func BenchmarkRangeWideTreeInsertBefore(b *testing.B) {
+	doc := NewDocument("goml")
+	parent := doc.CreateElement("div")
+	doc.AppendChild(parent)
+
+	children := make([]Node, wideTreeSize)
+	for i := range children {
+		children[i] = doc.CreateElement("p")
+		parent.AppendChild(children[i])
+	}
+	middle := children[wideTreeSize/2]
+
+	// A range outside of the mutated parent
+	r := doc.CreateRange()
+	defer r.Release()
+	r.SelectNodeContents(children[0])
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		parent.InsertBefore(doc.CreateElement("p"), middle)
+	}
+}
@@ -0,0 +1,318 @@
+package gom
+
+import (
+	"strings"
+
+	e "github.com/negrel/gom/exception"
+)
+
+// combinator is the relation between two compound
+// selectors of a complex selector.
+// https://drafts.csswg.org/selectors-4/#combinators
+type combinator uint8
+
+// Combinator list
+const (
+	noCombinator combinator = iota
+	descendantCombinator
+	childCombinator
+	adjacentSiblingCombinator
+	generalSiblingCombinator
+)
+
+// selectorList is a comma-separated list of complex
+// selectors, it match an element if any of its
+// selectors match.
+// https://drafts.csswg.org/selectors-4/#selector-list
+type selectorList []*complexSelector
+
+// match report whether el match the selector list. The
+// scope element is the element matched by :scope.
+func (sl selectorList) match(el, scope Element) bool {
+	for _, complex := range sl {
+		if complex.match(el, scope) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// complexSelector is a sequence of compound selectors
+// separated by combinators.
+// https://drafts.csswg.org/selectors-4/#complex
+type complexSelector struct {
+	compounds []*compoundSelector
+}
+
+func (cs *complexSelector) match(el, scope Element) bool {
+	return cs.matchAt(len(cs.compounds)-1, el, scope)
+}
+
+// matchAt match the compound selector at index i against
+// el and the compound selectors at the left of i against
+// the elements related to el.
+func (cs *complexSelector) matchAt(i int, el, scope Element) bool {
+	compound := cs.compounds[i]
+
+	if !compound.match(el, scope) {
+		return false
+	}
+
+	switch compound.combinator {
+	case descendantCombinator:
+		for ancestor := el.ParentElement(); ancestor != nil; ancestor = ancestor.ParentElement() {
+			if cs.matchAt(i-1, ancestor, scope) {
+				return true
+			}
+		}
+
+	case childCombinator:
+		if parent := el.ParentElement(); parent != nil {
+			return cs.matchAt(i-1, parent, scope)
+		}
+
+	case adjacentSiblingCombinator:
+		if sibling := el.PreviousElementSibling(); sibling != nil {
+			return cs.matchAt(i-1, sibling, scope)
+		}
+
+	case generalSiblingCombinator:
+		for sibling := el.PreviousElementSibling(); sibling != nil; sibling = sibling.PreviousElementSibling() {
+			if cs.matchAt(i-1, sibling, scope) {
+				return true
+			}
+		}
+
+	default:
+		// Left most compound selector
+		return true
+	}
+
+	return false
+}
+
+// compoundSelector is a sequence of simple selectors
+// that are not separated by a combinator.
+// https://drafts.csswg.org/selectors-4/#compound
+type compoundSelector struct {
+	// combinator relating this compound to the previous one.
+	combinator combinator
+	// tagName is empty for the universal selector.
+	tagName string
+	simples []simpleSelector
+}
+
+func (cs *compoundSelector) match(el, scope Element) bool {
+	if cs.tagName != "" && cs.tagName != el.TagName() {
+		return false
+	}
+
+	for _, simple := range cs.simples {
+		if !simple.match(el, scope) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// simpleSelector is a single condition on an element.
+// https://drafts.csswg.org/selectors-4/#simple
+type simpleSelector interface {
+	match(el, scope Element) bool
+}
+
+// idSelector match elements by their id attribute.
+type idSelector string
+
+func (id idSelector) match(el, _ Element) bool {
+	attr := el.Id()
+
+	return attr != nil && attr.Value() == string(id)
+}
+
+// classSelector match elements having the class.
+type classSelector string
+
+func (class classSelector) match(el, _ Element) bool {
+	for _, c := range el.ClassList() {
+		if c == string(class) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// attrSelector match elements by their attributes.
+// https://drafts.csswg.org/selectors-4/#attribute-selectors
+type attrSelector struct {
+	name string
+	// operator is empty for the presence selector.
+	operator        string
+	value           string
+	caseInsensitive bool
+}
+
+func (as *attrSelector) match(el, _ Element) bool {
+	attr := el.GetAttribute(as.name)
+	if attr == nil {
+		return false
+	}
+
+	value, expected := attr.Value(), as.value
+	if as.caseInsensitive {
+		value, expected = strings.ToLower(value), strings.ToLower(expected)
+	}
+
+	switch as.operator {
+	case "":
+		return true
+
+	case "=":
+		return value == expected
+
+	case "~=":
+		for _, word := range strings.Fields(value) {
+			if word == expected {
+				return true
+			}
+		}
+		return false
+
+	case "|=":
+		return value == expected || strings.HasPrefix(value, expected+"-")
+
+	case "^=":
+		return expected != "" && strings.HasPrefix(value, expected)
+
+	case "$=":
+		return expected != "" && strings.HasSuffix(value, expected)
+
+	case "*=":
+		return expected != "" && strings.Contains(value, expected)
+	}
+
+	return false
+}
+
+/*****************************************************
+ ******************** Selector ***********************
+ *****************************************************/
+// ANCHOR Selector
+
+// Selector is a compiled selector list that can be
+// matched against elements without being parsed again.
+type Selector interface {
+	/* METHODS */
+	Match(Element) bool
+	Query(Node) Element
+	QueryAll(Node) NodeList
+	String() string
+}
+
+var _ Selector = &compiledSelector{}
+
+type compiledSelector struct {
+	source string
+	list   selectorList
+}
+
+// CompileSelector parse the given selector list and return
+// a reusable Selector. A SyntaxError exception is returned
+// if the selector is invalid.
+func CompileSelector(source string) (Selector, e.Exception) {
+	list, err := parseSelector(source)
+	if err != nil {
+		return nil, err
+	}
+
+	return &compiledSelector{
+		source: source,
+		list:   list,
+	}, nil
+}
+
+// compileSelector return the compiled selector using the
+// selector cache of the document owning n if any.
+func compileSelector(n Node, source string) (Selector, e.Exception) {
+	doc, isDocument := n.(Document)
+	if !isDocument {
+		doc = n.OwnerDocument()
+	}
+
+	if doc == nil {
+		return CompileSelector(source)
+	}
+
+	return doc.compileSelector(source)
+}
+
+// Match report whether the element match the selector.
+// The element is the :scope element.
+func (s *compiledSelector) Match(el Element) bool {
+	return s.list.match(el, el)
+}
+
+// Query return the first descendant element of root
+// matching the selector.
+func (s *compiledSelector) Query(root Node) Element {
+	scope, _ := root.(Element)
+
+	var result Element
+	descendants(root, func(n Node) bool {
+		if el, isElement := n.(Element); isElement && s.list.match(el, scope) {
+			result = el
+			return false
+		}
+
+		return true
+	})
+
+	return result
+}
+
+// QueryAll return a static NodeList of all the descendant
+// elements of root matching the selector.
+func (s *compiledSelector) QueryAll(root Node) NodeList {
+	scope, _ := root.(Element)
+
+	result := newNodeList()
+	descendants(root, func(n Node) bool {
+		if el, isElement := n.(Element); isElement && s.list.match(el, scope) {
+			result.append(el)
+		}
+
+		return true
+	})
+
+	return result
+}
+
+// String return the source of the selector.
+func (s *compiledSelector) String() string {
+	return s.source
+}
+
+// querySelector return the first descendant element of
+// root matching the selector.
+func querySelector(root Node, source string) (Element, e.Exception) {
+	s, err := compileSelector(root, source)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.Query(root), nil
+}
+
+// querySelectorAll return a static NodeList of all the
+// descendant elements of root matching the selector.
+func querySelectorAll(root Node, source string) (NodeList, e.Exception) {
+	s, err := compileSelector(root, source)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.QueryAll(root), nil
+}
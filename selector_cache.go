@@ -0,0 +1,59 @@
+package gom
+
+import (
+	"container/list"
+
+	e "github.com/negrel/gom/exception"
+)
+
+// selectorCacheSize is the maximum number of compiled
+// selectors kept by a document.
+const selectorCacheSize = 256
+
+// selectorCache is a least recently used cache of
+// compiled selectors.
+type selectorCache struct {
+	capacity int
+	// order contains the cached selectors, most recently
+	// used first.
+	order   *list.List
+	entries map[string]*list.Element
+}
+
+func newSelectorCache(capacity int) *selectorCache {
+	return &selectorCache{
+		capacity: capacity,
+		order:    list.New(),
+		entries:  make(map[string]*list.Element, capacity),
+	}
+}
+
+// compile return the cached compiled selector or compile
+// and cache it. Invalid selectors are not cached.
+func (sc *selectorCache) compile(source string) (Selector, e.Exception) {
+	if entry, ok := sc.entries[source]; ok {
+		sc.order.MoveToFront(entry)
+		return entry.Value.(Selector), nil
+	}
+
+	s, err := CompileSelector(source)
+	if err != nil {
+		return nil, err
+	}
+
+	sc.entries[source] = sc.order.PushFront(s)
+
+	// Evicting the least recently used selector
+	if sc.order.Len() > sc.capacity {
+		oldest := sc.order.Back()
+		sc.order.Remove(oldest)
+		delete(sc.entries, oldest.Value.(Selector).String())
+	}
+
+	return s, nil
+}
+
+// len return the number of cached selectors.
+func (sc *selectorCache) len() int {
+	return sc.order.Len()
+}
@@ -0,0 +1,489 @@
+package gom
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	e "github.com/negrel/gom/exception"
+)
+
+// parseSelector parse a selector list.
+// A SyntaxError exception is returned if the selector
+// is invalid.
+func parseSelector(selector string) (selectorList, e.Exception) {
+	p := &selectorParser{src: selector}
+
+	list, err := p.parseSelectorList()
+	if err != nil {
+		return nil, err
+	}
+
+	if !p.eof() {
+		return nil, p.unexpected()
+	}
+
+	return list, nil
+}
+
+// selectorParser is a recursive descent parser for
+// selectors.
+// https://drafts.csswg.org/selectors-4/#grammar
+type selectorParser struct {
+	src    string
+	offset int
+}
+
+func (p *selectorParser) eof() bool {
+	return p.offset >= len(p.src)
+}
+
+// peek return the next rune without consuming it.
+func (p *selectorParser) peek() rune {
+	r, _ := utf8.DecodeRuneInString(p.src[p.offset:])
+	return r
+}
+
+// consume the given prefix if the unread source start
+// with it.
+func (p *selectorParser) consume(prefix string) bool {
+	if strings.HasPrefix(p.src[p.offset:], prefix) {
+		p.offset += len(prefix)
+		return true
+	}
+
+	return false
+}
+
+// skipSpaces consume the whitespaces and report
+// whether any was consumed.
+func (p *selectorParser) skipSpaces() bool {
+	start := p.offset
+
+	for !p.eof() && unicode.IsSpace(p.peek()) {
+		p.offset += utf8.RuneLen(p.peek())
+	}
+
+	return p.offset > start
+}
+
+// errorf return a SyntaxError exception.
+func (p *selectorParser) errorf(format string, args ...interface{}) e.Exception {
+	args = append([]interface{}{p.src}, args...)
+
+	return e.New(e.SyntaxError, "%q is not a valid selector: "+format, args...)
+}
+
+// unexpected return a SyntaxError exception for the
+// unexpected next rune.
+func (p *selectorParser) unexpected() e.Exception {
+	if p.eof() {
+		return p.errorf("unexpected end of selector")
+	}
+
+	return p.errorf("unexpected %q at offset %v", p.peek(), p.offset)
+}
+
+// isNameRune report whether r can be part of an identifier.
+func isNameRune(r rune) bool {
+	return r == '-' || r == '_' || r >= 0x80 || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// parseIdent consume an identifier.
+// https://drafts.csswg.org/css-syntax-3/#ident-token-diagram
+func (p *selectorParser) parseIdent() (string, e.Exception) {
+	var b strings.Builder
+
+	for !p.eof() {
+		r := p.peek()
+
+		// Escaped rune
+		if r == '\\' {
+			p.offset++
+			if p.eof() {
+				return "", p.unexpected()
+			}
+			r = p.peek()
+		} else if !isNameRune(r) {
+			break
+		}
+
+		b.WriteRune(r)
+		p.offset += utf8.RuneLen(r)
+	}
+
+	ident := b.String()
+	if ident == "" || ident == "-" || unicode.IsDigit([]rune(ident)[0]) ||
+		(ident[0] == '-' && unicode.IsDigit([]rune(ident)[1])) {
+		return "", p.errorf("invalid identifier at offset %v", p.offset-len(ident))
+	}
+
+	return ident, nil
+}
+
+// parseString consume a quoted string.
+func (p *selectorParser) parseString() (string, e.Exception) {
+	quote := p.peek()
+	p.offset++
+
+	var b strings.Builder
+	for !p.eof() {
+		r := p.peek()
+		p.offset += utf8.RuneLen(r)
+
+		switch r {
+		case quote:
+			return b.String(), nil
+
+		case '\\':
+			if p.eof() {
+				return "", p.unexpected()
+			}
+			r = p.peek()
+			p.offset += utf8.RuneLen(r)
+		}
+
+		b.WriteRune(r)
+	}
+
+	return "", p.errorf("unclosed string")
+}
+
+// parseSelectorList consume a comma-separated list of
+// complex selectors.
+func (p *selectorParser) parseSelectorList() (selectorList, e.Exception) {
+	list := make(selectorList, 0, 1)
+
+	for {
+		p.skipSpaces()
+
+		complex, err := p.parseComplexSelector()
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, complex)
+
+		if !p.consume(",") {
+			return list, nil
+		}
+	}
+}
+
+// parseComplexSelector consume compound selectors
+// separated by combinators.
+func (p *selectorParser) parseComplexSelector() (*complexSelector, e.Exception) {
+	complex := &complexSelector{}
+	comb := noCombinator
+
+	for {
+		compound, err := p.parseCompoundSelector()
+		if err != nil {
+			return nil, err
+		}
+		compound.combinator = comb
+		complex.compounds = append(complex.compounds, compound)
+
+		// Parsing the combinator
+		hasSpaces := p.skipSpaces()
+
+		switch {
+		case p.consume(">"):
+			comb = childCombinator
+		case p.consume("+"):
+			comb = adjacentSiblingCombinator
+		case p.consume("~"):
+			comb = generalSiblingCombinator
+		case hasSpaces && !p.eof() && p.peek() != ',' && p.peek() != ')':
+			comb = descendantCombinator
+		default:
+			// End of the complex selector
+			return complex, nil
+		}
+
+		p.skipSpaces()
+	}
+}
+
+// parseCompoundSelector consume a type selector followed
+// by simple selectors.
+func (p *selectorParser) parseCompoundSelector() (*compoundSelector, e.Exception) {
+	compound := &compoundSelector{}
+	start := p.offset
+
+	// Type selector
+	if p.consume("*") {
+		compound.tagName = ""
+	} else if !p.eof() && (isNameRune(p.peek()) || p.peek() == '\\') {
+		tagName, err := p.parseIdent()
+		if err != nil {
+			return nil, err
+		}
+		compound.tagName = strings.ToLower(tagName)
+	}
+
+	for !p.eof() {
+		var simple simpleSelector
+		var err e.Exception
+
+		switch p.peek() {
+		case '#':
+			p.offset++
+			var id string
+			id, err = p.parseIdent()
+			simple = idSelector(id)
+
+		case '.':
+			p.offset++
+			var class string
+			class, err = p.parseIdent()
+			simple = classSelector(class)
+
+		case '[':
+			simple, err = p.parseAttrSelector()
+
+		case ':':
+			simple, err = p.parsePseudoClass()
+
+		default:
+			goto end
+		}
+
+		if err != nil {
+			return nil, err
+		}
+		compound.simples = append(compound.simples, simple)
+	}
+
+end:
+	// Empty compound selector
+	if p.offset == start {
+		return nil, p.unexpected()
+	}
+
+	return compound, nil
+}
+
+// parseAttrSelector consume an attribute selector.
+func (p *selectorParser) parseAttrSelector() (simpleSelector, e.Exception) {
+	p.consume("[")
+	p.skipSpaces()
+
+	name, err := p.parseIdent()
+	if err != nil {
+		return nil, err
+	}
+	attr := &attrSelector{name: strings.ToLower(name)}
+
+	p.skipSpaces()
+	if p.consume("]") {
+		return attr, nil
+	}
+
+	for _, operator := range []string{"=", "~=", "|=", "^=", "$=", "*="} {
+		if p.consume(operator) {
+			attr.operator = operator
+			break
+		}
+	}
+	if attr.operator == "" {
+		return nil, p.unexpected()
+	}
+
+	p.skipSpaces()
+	if p.eof() {
+		return nil, p.unexpected()
+	}
+
+	if r := p.peek(); r == '"' || r == '\'' {
+		attr.value, err = p.parseString()
+	} else {
+		attr.value, err = p.parseIdent()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Case-sensitivity flag
+	p.skipSpaces()
+	if p.consume("i") || p.consume("I") {
+		attr.caseInsensitive = true
+	} else if !p.consume("s") {
+		p.consume("S")
+	}
+
+	p.skipSpaces()
+	if !p.consume("]") {
+		return nil, p.unexpected()
+	}
+
+	return attr, nil
+}
+
+// parsePseudoClass consume a pseudo-class.
+func (p *selectorParser) parsePseudoClass() (simpleSelector, e.Exception) {
+	start := p.offset
+	p.consume(":")
+
+	name, err := p.parseIdent()
+	if err != nil {
+		return nil, err
+	}
+	name = strings.ToLower(name)
+
+	// Pseudo-class without argument
+	if !p.consume("(") {
+		if pseudo, ok := pseudoClasses[name]; ok {
+			return pseudo, nil
+		}
+
+		return nil, p.errorf("unknown pseudo-class %q at offset %v", ":"+name, start)
+	}
+
+	var simple simpleSelector
+	p.skipSpaces()
+
+	switch name {
+	case "not":
+		var list selectorList
+		list, err = p.parseSelectorList()
+		simple = &notSelector{list}
+
+	case "is", "where":
+		var list selectorList
+		list, err = p.parseSelectorList()
+		simple = &isSelector{list}
+
+	case "has":
+		simple, err = p.parseRelativeSelectorList()
+
+	case "nth-child", "nth-last-child", "nth-of-type", "nth-last-of-type":
+		nth := &nthSelector{
+			last:   strings.HasPrefix(name, "nth-last"),
+			ofType: strings.HasSuffix(name, "of-type"),
+		}
+		simple = nth
+
+		nth.a, nth.b, err = p.parseNth()
+		if err == nil && !nth.ofType && p.skipSpaces() && p.consume("of") {
+			if !p.skipSpaces() {
+				return nil, p.unexpected()
+			}
+			nth.of, err = p.parseSelectorList()
+		}
+
+	default:
+		return nil, p.errorf("unknown pseudo-class %q at offset %v", ":"+name+"()", start)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	p.skipSpaces()
+	if !p.consume(")") {
+		return nil, p.unexpected()
+	}
+
+	return simple, nil
+}
+
+// parseRelativeSelectorList consume the relative selector
+// list argument of :has().
+// https://drafts.csswg.org/selectors-4/#relative
+func (p *selectorParser) parseRelativeSelectorList() (*hasSelector, e.Exception) {
+	has := &hasSelector{}
+
+	for {
+		p.skipSpaces()
+
+		// Leading combinator
+		comb := descendantCombinator
+		switch {
+		case p.consume(">"):
+			comb = childCombinator
+		case p.consume("+"):
+			comb = adjacentSiblingCombinator
+			has.siblings = true
+		case p.consume("~"):
+			comb = generalSiblingCombinator
+			has.siblings = true
+		}
+		p.skipSpaces()
+
+		complex, err := p.parseComplexSelector()
+		if err != nil {
+			return nil, err
+		}
+
+		// The anchor element is matched by :scope
+		complex.compounds[0].combinator = comb
+		anchor := &compoundSelector{
+			simples: []simpleSelector{pseudoClasses["scope"]},
+		}
+		complex.compounds = append([]*compoundSelector{anchor}, complex.compounds...)
+
+		has.list = append(has.list, complex)
+
+		if !p.consume(",") {
+			return has, nil
+		}
+	}
+}
+
+// nthPattern match the an+b microsyntax. Whitespaces
+// are only allowed around the sign between the an and b
+// terms.
+// https://drafts.csswg.org/css-syntax-3/#anb-microsyntax
+var nthPattern = regexp.MustCompile(`^(?:([+-]?\d*)n(?:\s*([+-])\s*(\d+))?|([+-]?\d+))$`)
+
+// parseNth consume an an+b argument.
+func (p *selectorParser) parseNth() (a, b int, err e.Exception) {
+	start := p.offset
+
+	// Keywords
+	for keyword, ab := range map[string][2]int{"odd": {2, 1}, "even": {2, 0}} {
+		if end := p.offset + len(keyword); end <= len(p.src) &&
+			strings.EqualFold(p.src[p.offset:end], keyword) &&
+			(end == len(p.src) || !isNameRune(rune(p.src[end]))) {
+			p.offset = end
+			return ab[0], ab[1], nil
+		}
+	}
+
+	for !p.eof() && (strings.ContainsRune("0123456789nN+-", p.peek()) || unicode.IsSpace(p.peek())) {
+		p.offset++
+	}
+	// Trailing whitespaces are not part of the expression
+	for p.offset > start && unicode.IsSpace(rune(p.src[p.offset-1])) {
+		p.offset--
+	}
+
+	expr := strings.ToLower(strings.TrimSpace(p.src[start:p.offset]))
+	match := nthPattern.FindStringSubmatch(expr)
+	if match == nil {
+		return 0, 0, p.errorf("invalid an+b expression %q at offset %v", expr, start)
+	}
+
+	// Integer only
+	if match[4] != "" {
+		b, _ = strconv.Atoi(match[4])
+		return 0, b, nil
+	}
+
+	switch match[1] {
+	case "", "+":
+		a = 1
+	case "-":
+		a = -1
+	default:
+		a, _ = strconv.Atoi(match[1])
+	}
+
+	if match[3] != "" {
+		b, _ = strconv.Atoi(match[2] + match[3])
+	}
+
+	return a, b, nil
+}
@@ -0,0 +1,178 @@
+package gom
+
+// pseudoClass is a pseudo-class without argument.
+// https://drafts.csswg.org/selectors-4/#pseudo-classes
+type pseudoClass func(el, scope Element) bool
+
+func (pc pseudoClass) match(el, scope Element) bool {
+	return pc(el, scope)
+}
+
+// pseudoClasses contains the supported pseudo-classes
+// without argument.
+var pseudoClasses = map[string]simpleSelector{
+	"first-child":   &nthSelector{b: 1},
+	"last-child":    &nthSelector{b: 1, last: true},
+	"only-child":    allOf{&nthSelector{b: 1}, &nthSelector{b: 1, last: true}},
+	"first-of-type": &nthSelector{b: 1, ofType: true},
+	"last-of-type":  &nthSelector{b: 1, last: true, ofType: true},
+	"only-of-type":  allOf{&nthSelector{b: 1, ofType: true}, &nthSelector{b: 1, last: true, ofType: true}},
+	"empty":         pseudoClass(matchEmpty),
+	"root":          pseudoClass(matchRoot),
+	"scope":         pseudoClass(matchScope),
+}
+
+// matchEmpty report whether el has no element or
+// non-empty text child.
+// https://drafts.csswg.org/selectors-4/#the-empty-pseudo
+func matchEmpty(el, _ Element) bool {
+	for child := el.FirstChild(); child != nil; child = child.NextSibling() {
+		switch child.NodeType() {
+		case ElementNode:
+			return false
+
+		case TextNode:
+			if child.(Text).Length() > 0 {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// matchRoot report whether el is the document element.
+// https://drafts.csswg.org/selectors-4/#the-root-pseudo
+func matchRoot(el, _ Element) bool {
+	parent := el.ParentNode()
+
+	return parent != nil && parent.NodeType() == DocumentNode
+}
+
+// matchScope report whether el is the scope element or
+// the document element if there is no scope.
+// https://drafts.csswg.org/selectors-4/#the-scope-pseudo
+func matchScope(el, scope Element) bool {
+	if scope == nil {
+		return matchRoot(el, nil)
+	}
+
+	return el.IsSameNode(scope)
+}
+
+// allOf match elements matching all the simple selectors.
+type allOf []simpleSelector
+
+func (all allOf) match(el, scope Element) bool {
+	for _, simple := range all {
+		if !simple.match(el, scope) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// nthSelector match elements whose index among their
+// siblings is a*n+b for some positive or zero n.
+// https://drafts.csswg.org/selectors-4/#child-index
+type nthSelector struct {
+	a, b int
+	// last is true to count from the last sibling.
+	last bool
+	// ofType is true to count only the siblings with
+	// the same tag name.
+	ofType bool
+	// of is the selector the counted siblings must
+	// match, nil to count all siblings.
+	of selectorList
+}
+
+func (ns *nthSelector) match(el, scope Element) bool {
+	if ns.of != nil && !ns.of.match(el, scope) {
+		return false
+	}
+
+	next := Element.PreviousElementSibling
+	if ns.last {
+		next = Element.NextElementSibling
+	}
+
+	// Computing the 1-based index of el
+	index := 1
+	for sibling := next(el); sibling != nil; sibling = next(sibling) {
+		if ns.ofType && sibling.TagName() != el.TagName() {
+			continue
+		}
+		if ns.of != nil && !ns.of.match(sibling, scope) {
+			continue
+		}
+
+		index++
+	}
+
+	if ns.a == 0 {
+		return index == ns.b
+	}
+
+	n := index - ns.b
+
+	return n%ns.a == 0 && n/ns.a >= 0
+}
+
+// notSelector match elements not matching the selector list.
+// https://drafts.csswg.org/selectors-4/#negation
+type notSelector struct {
+	list selectorList
+}
+
+func (ns *notSelector) match(el, scope Element) bool {
+	return !ns.list.match(el, scope)
+}
+
+// isSelector match elements matching the selector list.
+// It is used for both :is() and :where() as specificity
+// is not computed.
+// https://drafts.csswg.org/selectors-4/#matches
+type isSelector struct {
+	list selectorList
+}
+
+func (is *isSelector) match(el, scope Element) bool {
+	return is.list.match(el, scope)
+}
+
+// hasSelector match elements that anchor at least one
+// element matching the relative selector list.
+// https://drafts.csswg.org/selectors-4/#relational
+type hasSelector struct {
+	// list contains relative selectors whose left most
+	// compound match the anchor element.
+	list selectorList
+	// siblings is true if a relative selector start
+	// with a sibling combinator.
+	siblings bool
+}
+
+func (hs *hasSelector) match(el, _ Element) bool {
+	found := false
+	matchCandidate := func(n Node) bool {
+		if candidate, isElement := n.(Element); isElement && hs.list.match(candidate, el) {
+			found = true
+		}
+
+		return !found
+	}
+
+	descendants(el, matchCandidate)
+
+	if !found && hs.siblings {
+		for sibling := el.NextElementSibling(); sibling != nil && !found; sibling = sibling.NextElementSibling() {
+			if matchCandidate(sibling) {
+				descendants(sibling, matchCandidate)
+			}
+		}
+	}
+
+	return found
+}
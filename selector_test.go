@@ -0,0 +1,311 @@
+package gom
+
+import (
+	"strings"
+	"testing"
+)
+
+const selectorTestSrc = `<html>
+<body>
+  <div id="main" class="panel dark">
+    <h1 lang="en-US">Title</h1>
+    <p class="intro" data-kind="first item">One</p>
+    <p data-kind="second">Two</p>
+    <span>Three</span>
+    <ul>
+      <li><a href="https://example.com/doc.pdf">PDF</a></li>
+      <li><a href="/local">Local</a></li>
+    </ul>
+  </div>
+  <p id="footer">Four</p>
+</body>
+</html>`
+
+// texts return the text content of the first child of
+// each node of the list or its tag name if the element
+// doesn't start with a text.
+func texts(list NodeList) []string {
+	result := make([]string, 0, list.Length())
+
+	for _, node := range list.Values() {
+		if text, isText := node.FirstChild().(Text); isText && strings.TrimSpace(text.Data()) != "" {
+			result = append(result, text.Data())
+		} else {
+			result = append(result, "<"+node.(Element).TagName()+">")
+		}
+	}
+
+	return result
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+func TestQuerySelectorAll(t *testing.T) {
+	doc := parseTestDocument(t, selectorTestSrc)
+
+	tests := []struct {
+		selector string
+		expected []string
+	}{
+		{"p", []string{"One", "Two", "Four"}},
+		{"P", []string{"One", "Two", "Four"}},
+		{"#footer", []string{"Four"}},
+		{".intro", []string{"One"}},
+		{"div.panel.dark > h1", []string{"Title"}},
+		{".panel.light", []string{}},
+		{"[data-kind]", []string{"One", "Two"}},
+		{"[data-kind=second]", []string{"Two"}},
+		{"[data-kind='SECOND' i]", []string{"Two"}},
+		{"[data-kind~=item]", []string{"One"}},
+		{"[lang|=en]", []string{"Title"}},
+		{"a[href^=\"https://\"]", []string{"PDF"}},
+		{"a[href$='.pdf']", []string{"PDF"}},
+		{"a[href*=loc]", []string{"Local"}},
+		{"body p", []string{"One", "Two", "Four"}},
+		{"body > p", []string{"Four"}},
+		{"h1 + p", []string{"One"}},
+		{"h1 ~ p", []string{"One", "Two"}},
+		{"div ul li > a", []string{"PDF", "Local"}},
+		{"span, #footer, h1", []string{"Title", "Three", "Four"}},
+		{"*", []string{"<html>", "<body>", "<div>", "Title", "One", "Two", "Three", "<ul>", "<li>", "PDF", "<li>", "Local", "Four"}},
+	}
+
+	for _, test := range tests {
+		list, err := doc.QuerySelectorAll(test.selector)
+		if err != nil {
+			t.Logf("Query %q must not return an error : %v", test.selector, err)
+			t.Fail()
+			continue
+		}
+
+		if result := texts(list); !equalStrings(result, test.expected) {
+			t.Logf("Query %q must return %v, got %v.", test.selector, test.expected, result)
+			t.Fail()
+		}
+	}
+}
+
+func TestQuerySelector(t *testing.T) {
+	doc := parseTestDocument(t, selectorTestSrc)
+	main, _ := doc.QuerySelector("#main")
+
+	// Element query are scoped to the element descendants
+	p, err := main.QuerySelector("body p")
+	if err != nil {
+		t.Fatalf("Error while querying : %v", err)
+	}
+
+	if p == nil || p.FirstChild().(Text).Data() != "One" {
+		t.Log("First \"body p\" descendant of #main must be the \"One\" paragraph.")
+		t.Fail()
+	}
+
+	if footer, _ := main.QuerySelector("#footer"); footer != nil {
+		t.Log("#footer is not a descendant of #main.")
+		t.Fail()
+	}
+}
+
+func TestQuerySelectorError(t *testing.T) {
+	doc := parseTestDocument(t, selectorTestSrc)
+
+	for _, selector := range []string{
+		"", "p >", "> p", "p,", "#", ".1a", "[href", "[href=]", "[href=='a']",
+		"a[href=\"x]", "p:unknown", "p!", "a,,b",
+	} {
+		_, err := doc.QuerySelectorAll(selector)
+
+		if err == nil || err.Name() != "SyntaxError" {
+			t.Logf("Query %q must return a SyntaxError, got %v.", selector, err)
+			t.Fail()
+		}
+	}
+}
+
+func TestQuerySelectorAllPseudoClasses(t *testing.T) {
+	doc := parseTestDocument(t, `<ul>`+
+		`<li class="a">1</li><li>2</li><li class="a">3</li><li>4</li><li class="a">5</li>`+
+		`<li><b>6</b></li><li><!-- empty --></li><li>8</li>`+
+		`</ul>`)
+
+	tests := []struct {
+		selector string
+		expected []string
+	}{
+		{"li:first-child", []string{"1"}},
+		{"li:last-child", []string{"8"}},
+		{"b:only-child", []string{"6"}},
+		{"li:only-child", []string{}},
+		{"li:nth-child(2)", []string{"2"}},
+		{"li:nth-child(odd)", []string{"1", "3", "5", "<li>"}},
+		{"li:nth-child(EVEN)", []string{"2", "4", "<li>", "8"}},
+		{"li:nth-child(3n + 1)", []string{"1", "4", "<li>"}},
+		{"li:nth-child(-n+3)", []string{"1", "2", "3"}},
+		{"li:nth-child(3n- 2)", []string{"1", "4", "<li>"}},
+		{"li:nth-child(n+7)", []string{"<li>", "8"}},
+		{"li:nth-child(2 of .a)", []string{"3"}},
+		{"li:nth-last-child(2)", []string{"<li>"}},
+		{"li:nth-last-child(-n+2 of .a)", []string{"3", "5"}},
+		{"li:nth-of-type(2)", []string{"2"}},
+		{"li:first-of-type, b:last-of-type", []string{"1", "6"}},
+		{"li:empty", []string{"<li>"}},
+		{":root", []string{"<ul>"}},
+		{"li:not(.a)", []string{"2", "4", "<li>", "<li>", "8"}},
+		{"li:not(.a, :empty, :has(b))", []string{"2", "4", "8"}},
+		{":is(li.a, b)", []string{"1", "3", "5", "6"}},
+		{"ul > :where(:first-child, :last-child)", []string{"1", "8"}},
+		{"li:has(> b)", []string{"<li>"}},
+		{"ul:has(b)", []string{"<ul>"}},
+		{"li:has(+ .a)", []string{"2", "4"}},
+		{"li:has(~ li b)", []string{"1", "2", "3", "4", "5"}},
+	}
+
+	for _, test := range tests {
+		list, err := doc.QuerySelectorAll(test.selector)
+		if err != nil {
+			t.Logf("Query %q must not return an error : %v", test.selector, err)
+			t.Fail()
+			continue
+		}
+
+		if result := texts(list); !equalStrings(result, test.expected) {
+			t.Logf("Query %q must return %v, got %v.", test.selector, test.expected, result)
+			t.Fail()
+		}
+	}
+
+	for _, selector := range []string{
+		":nth-child", ":nth-child()", ":nth-child(n+)", ":nth-child(2n of)", ":not()", ":is(a", ":has()", ":unknown()",
+		":nth-child(2 n)", ":nth-child(- n+1)", ":nth-child(+ 2)", ":nth-child(2n+ -1)", ":nth-child(1 2)",
+	} {
+		if _, err := doc.QuerySelectorAll(selector); err == nil || err.Name() != "SyntaxError" {
+			t.Logf("Query %q must return a SyntaxError, got %v.", selector, err)
+			t.Fail()
+		}
+	}
+}
+
+func TestQuerySelectorScope(t *testing.T) {
+	doc := parseTestDocument(t, `<div><p><span>a</span></p><span>b</span></div>`)
+	p, _ := doc.QuerySelector("p")
+
+	list, err := doc.DocumentElement().QuerySelectorAll(":scope > span")
+	if err != nil {
+		t.Fatalf("Error while querying : %v", err)
+	}
+
+	if result := texts(list); !equalStrings(result, []string{"b"}) {
+		t.Logf("Query \":scope > span\" must return [b], got %v.", result)
+		t.Fail()
+	}
+
+	if span, _ := p.QuerySelector(":scope > span"); span == nil {
+		t.Log("Query \":scope > span\" on <p> must return its span child.")
+		t.Fail()
+	}
+}
+
+func TestCompileSelector(t *testing.T) {
+	doc := parseTestDocument(t, selectorTestSrc)
+
+	selector, err := CompileSelector("div > p")
+	if err != nil {
+		t.Fatalf("Error while compiling the selector : %v", err)
+	}
+
+	if selector.String() != "div > p" {
+		t.Logf("Selector string must be its source, got %q.", selector.String())
+		t.Fail()
+	}
+
+	if result := texts(selector.QueryAll(doc)); !equalStrings(result, []string{"One", "Two"}) {
+		t.Logf("Selector must query [One Two], got %v.", result)
+		t.Fail()
+	}
+
+	footer, _ := doc.QuerySelector("#footer")
+	if selector.Match(footer) {
+		t.Log("Selector must not match the #footer paragraph.")
+		t.Fail()
+	}
+
+	if first := selector.Query(doc); first == nil || !selector.Match(first) {
+		t.Log("Selector must match the first element it query.")
+		t.Fail()
+	}
+
+	/*
+	 * Testing error
+	 */
+
+	if _, err := CompileSelector("div >"); err == nil || err.Name() != "SyntaxError" {
+		t.Logf("Compiling an invalid selector must return a SyntaxError, got %v.", err)
+		t.Fail()
+	}
+}
+
+func TestSelectorCache(t *testing.T) {
+	cache := newSelectorCache(2)
+
+	a, _ := cache.compile("a")
+	cache.compile("b")
+
+	// Checking that cached selectors are reused
+	if again, _ := cache.compile("a"); again != a {
+		t.Log("Compiling a cached selector must return the cached selector.")
+		t.Fail()
+	}
+
+	// "b" is the least recently used selector
+	cache.compile("c")
+
+	if cache.len() != 2 {
+		t.Logf("Cache length must be bounded to 2, got %v.", cache.len())
+		t.Fail()
+	}
+
+	if _, cached := cache.entries["b"]; cached {
+		t.Log("Least recently used selector must be evicted.")
+		t.Fail()
+	}
+
+	if again, _ := cache.compile("a"); again != a {
+		t.Log("Recently used selector must not be evicted.")
+		t.Fail()
+	}
+
+	// Invalid selectors are not cached
+	if _, err := cache.compile("["); err == nil || cache.len() != 2 {
+		t.Log("Invalid selector must return an error and must not be cached.")
+		t.Fail()
+	}
+}
+
+func BenchmarkQuerySelectorAll(b *testing.B) {
+	doc, _ := ParseDocument(strings.NewReader(selectorTestSrc))
+
+	for i := 0; i < b.N; i++ {
+		doc.QuerySelectorAll("div ul li:nth-child(odd) > a[href^='https://']")
+	}
+}
+
+func BenchmarkCompiledSelectorQueryAll(b *testing.B) {
+	doc, _ := ParseDocument(strings.NewReader(selectorTestSrc))
+	selector, _ := CompileSelector("div ul li:nth-child(odd) > a[href^='https://']")
+
+	for i := 0; i < b.N; i++ {
+		selector.QueryAll(doc)
+	}
+}
@@ -0,0 +1,170 @@
+package gom
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+var (
+	textEscaper = strings.NewReplacer(
+		"&", "&amp;",
+		"<", "&lt;",
+		">", "&gt;",
+		"\u00a0", "&nbsp;",
+	)
+	attrEscaper = strings.NewReplacer(
+		"&", "&amp;",
+		"\"", "&quot;",
+		"\u00a0", "&nbsp;",
+	)
+)
+
+// Serialize write the GOML markup of the given node and
+// its descendants to w. Document and DocumentFragment
+// are serialized as the list of their children.
+func Serialize(w io.Writer, n Node) error {
+	s := &serializer{w: w}
+	s.serialize(n)
+
+	return s.err
+}
+
+// serializeChildren return the GOML markup of the
+// children of the given node or an empty string if they
+// can't be serialized.
+func serializeChildren(n Node) string {
+	var b strings.Builder
+	s := &serializer{w: &b}
+	s.serializeChildren(n)
+
+	if s.err != nil {
+		return ""
+	}
+
+	return b.String()
+}
+
+// serializeNode return the GOML markup of the given node
+// or an empty string if it can't be serialized.
+func serializeNode(n Node) string {
+	var b strings.Builder
+	if err := Serialize(&b, n); err != nil {
+		return ""
+	}
+
+	return b.String()
+}
+
+// serializer write the GOML markup of nodes to a
+// writer. Writing stop at the first error.
+type serializer struct {
+	w   io.Writer
+	err error
+}
+
+func (s *serializer) write(str string) {
+	if s.err != nil {
+		return
+	}
+
+	_, s.err = io.WriteString(s.w, str)
+}
+
+// fail stop the serialization with the given error.
+func (s *serializer) fail(err error) {
+	if s.err == nil {
+		s.err = err
+	}
+}
+
+func (s *serializer) serialize(n Node) {
+	switch n.NodeType() {
+	case ElementNode:
+		s.serializeElement(n.(Element))
+
+	case TextNode:
+		s.serializeText(n.(Text))
+
+	case CommentNode:
+		// The comment would end early or not at all when parsed back.
+		data := n.(Comment).Data()
+		if strings.Contains(data, "-->") || strings.HasSuffix(data, "-") {
+			s.fail(fmt.Errorf("the data of the comment contains its end delimiter"))
+			return
+		}
+
+		s.write("<!--")
+		s.write(data)
+		s.write("-->")
+
+	case DocumentTypeNode:
+		s.write("<!DOCTYPE ")
+		s.write(n.(DocumentType).Name())
+		s.write(">")
+
+	case AttributeNode:
+		// Attributes are serialized by their owner element.
+
+	default:
+		// Document, DocumentFragment...
+		s.serializeChildren(n)
+	}
+}
+
+func (s *serializer) serializeChildren(n Node) {
+	for child := n.FirstChild(); child != nil; child = child.NextSibling() {
+		if s.err != nil {
+			return
+		}
+
+		s.serialize(child)
+	}
+}
+
+func (s *serializer) serializeElement(el Element) {
+	tagName := el.TagName()
+
+	s.write("<")
+	s.write(tagName)
+
+	for _, attr := range el.Attributes().Values() {
+		s.write(" ")
+		s.write(attr.Name())
+		s.write("=\"")
+		s.write(attrEscaper.Replace(attr.Value()))
+		s.write("\"")
+	}
+
+	s.write(">")
+
+	// Void elements have no end tag, their children
+	// can't be represented in the markup
+	if voidElements[tagName] {
+		return
+	}
+
+	// The raw text of script and style elements can't
+	// contain their end tag, it wouldn't be parsed back
+	if rawTextElements[tagName] &&
+		strings.Contains(strings.ToLower(el.TextContent()), "</"+tagName) {
+		s.fail(fmt.Errorf("the content of the <%v> element contains its end tag", tagName))
+		return
+	}
+
+	s.serializeChildren(el)
+
+	s.write("</")
+	s.write(tagName)
+	s.write(">")
+}
+
+func (s *serializer) serializeText(text Text) {
+	// The content of raw text elements is not escaped
+	if parent := text.ParentElement(); parent != nil && rawTextElements[parent.TagName()] {
+		s.write(text.Data())
+		return
+	}
+
+	s.write(textEscaper.Replace(text.Data()))
+}
@@ -0,0 +1,173 @@
+package gom
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestSerialize(t *testing.T) {
+	src := `<!DOCTYPE goml><html lang="en"><head><meta charset="UTF-8"><style>a > b {}</style></head>` +
+		`<body class="a &quot;b&quot;"><!-- comment --><p>1 &lt; 2 &amp;&amp; 3 &gt; 2</p></body></html>`
+
+	doc, err := ParseDocument(strings.NewReader(src))
+	if err != nil {
+		t.Fatalf("Error while parsing the document : %v", err)
+	}
+
+	var b strings.Builder
+	if err := Serialize(&b, doc); err != nil {
+		t.Fatalf("Error while serializing the document : %v", err)
+	}
+
+	if b.String() != src {
+		t.Log("Serialized document must be equal to the parsed source.")
+		t.Logf("Source     : %v", src)
+		t.Logf("Serialized : %v", b.String())
+		t.Fail()
+	}
+}
+
+func TestInnerOuterGOML(t *testing.T) {
+	doc := NewDocument("goml")
+	div := doc.CreateElement("div")
+	div.SetAttribute("id", "main")
+	div.AppendChild(doc.CreateTextNode("<Hello>"))
+	doc.AppendChild(div)
+
+	if inner := div.InnerGOML(); inner != "&lt;Hello&gt;" {
+		t.Logf("Div inner GOML must be \"&lt;Hello&gt;\", got %q.", inner)
+		t.Fail()
+	}
+
+	if outer := div.OuterGOML(); outer != `<div id="main">&lt;Hello&gt;</div>` {
+		t.Logf("Div outer GOML must be '<div id=\"main\">&lt;Hello&gt;</div>', got %q.", outer)
+		t.Fail()
+	}
+}
+
+func TestSerializeVoidElement(t *testing.T) {
+	doc := NewDocument("goml")
+	div := doc.CreateElement("div")
+	br := doc.CreateElement("br")
+	br.AppendChild(doc.CreateTextNode("text"))
+	div.AppendChild(br)
+
+	markup := div.OuterGOML()
+	if markup != "<div><br></div>" {
+		t.Logf("The children of void elements must not be serialized, got %q.", markup)
+		t.Fail()
+	}
+
+	parsed, err := ParseDocument(strings.NewReader(markup))
+	if err != nil {
+		t.Fatalf("The serialized markup must be parsed back : %v", err)
+	}
+
+	if outer := parsed.DocumentElement().OuterGOML(); outer != markup {
+		t.Logf("The parsed markup must be serialized to %q, got %q.", markup, outer)
+		t.Fail()
+	}
+}
+
+func TestSerializeRawText(t *testing.T) {
+	doc := NewDocument("goml")
+	div := doc.CreateElement("div")
+	script := doc.CreateElement("script")
+	script.AppendChild(doc.CreateTextNode("if (a < b) {}"))
+	div.AppendChild(script)
+
+	markup := div.OuterGOML()
+	parsed, err := ParseDocument(strings.NewReader(markup))
+	if err != nil {
+		t.Fatalf("The serialized markup must be parsed back : %v", err)
+	}
+
+	if outer := parsed.DocumentElement().OuterGOML(); outer != markup {
+		t.Logf("The parsed markup must be serialized to %q, got %q.", markup, outer)
+		t.Fail()
+	}
+
+	// The end tag can't be serialized in the raw text
+	for _, data := range []string{"a</script>b", "a</SCRIPT", "</scr"} {
+		script.SetTextContent("")
+		script.AppendChild(doc.CreateTextNode(data))
+		if data == "</scr" {
+			script.AppendChild(doc.CreateTextNode("ipt>"))
+		}
+
+		var b strings.Builder
+		if err := Serialize(&b, div); err == nil {
+			t.Logf("Serializing a script containing %q must return an error.", script.TextContent())
+			t.Fail()
+		}
+
+		if outer := div.OuterGOML(); outer != "" {
+			t.Logf("OuterGOML must return an empty string if the element can't be serialized, got %q.", outer)
+			t.Fail()
+		}
+	}
+}
+
+func TestSerializeComment(t *testing.T) {
+	doc := NewDocument("goml")
+	div := doc.CreateElement("div")
+	comment := doc.CreateComment("a - b -> c")
+	div.AppendChild(comment)
+
+	markup := div.OuterGOML()
+	parsed, err := ParseDocument(strings.NewReader(markup))
+	if err != nil {
+		t.Fatalf("The serialized markup must be parsed back : %v", err)
+	}
+
+	if outer := parsed.DocumentElement().OuterGOML(); outer != markup {
+		t.Logf("The parsed markup must be serialized to %q, got %q.", markup, outer)
+		t.Fail()
+	}
+
+	// The end delimiter can't be serialized in the comment data
+	for _, data := range []string{"a-->b", "-->", "a-", "a--"} {
+		comment.SetData(data)
+
+		var b strings.Builder
+		if err := Serialize(&b, div); err == nil {
+			t.Logf("Serializing a comment containing %q must return an error.", data)
+			t.Fail()
+		}
+
+		if outer := div.OuterGOML(); outer != "" {
+			t.Logf("OuterGOML must return an empty string if the comment can't be serialized, got %q.", outer)
+			t.Fail()
+		}
+	}
+}
+
+type failingWriter struct {
+	writes int
+}
+
+func (w *failingWriter) Write(p []byte) (int, error) {
+	w.writes++
+	return 0, errors.New("write failed")
+}
+
+func TestSerializeError(t *testing.T) {
+	doc, err := ParseDocument(strings.NewReader("<div><span>a</span><span>b</span></div>"))
+	if err != nil {
+		t.Fatalf("Error while parsing the document : %v", err)
+	}
+
+	w := &failingWriter{}
+
+	if err := Serialize(w, doc); err == nil {
+		t.Log("Serialize must return the writer error.")
+		t.Fail()
+	}
+
+	// Checking that serialization stop at the first error
+	if w.writes != 1 {
+		t.Logf("Serialize must stop writing after the first error, got %v writes.", w.writes)
+		t.Fail()
+	}
+}
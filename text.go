@@ -1,7 +1,6 @@
 package gom
 
 import (
-	"github.com/negrel/gom/exception"
 	e "github.com/negrel/gom/exception"
 )
 
@@ -24,7 +23,7 @@ type Text interface {
 	/* GETTERS & SETTERS (props) */
 	WholeText() string
 	/* METHODS */
-	SplitText(uint) (Text, exception.Exception)
+	SplitText(uint) (Text, e.Exception)
 }
 
 var _ Text = &text{}
@@ -35,11 +34,47 @@ type text struct {
 
 // createTextNode return a new Text node.
 func createTextNode(content string) Text {
-	return &text{
-		&characterData{
-			data: content,
-		},
+	t := &text{&characterData{}}
+	t.init(t, content)
+
+	return t
+}
+
+/*****************************************************
+ **************** Embedded interface *****************
+ *****************************************************/
+// ANCHOR Embedded interface
+
+/* Node */
+/* - Props */
+
+// NodeName return "#text".
+func (t *text) NodeName() string {
+	return "#text"
+}
+
+// NodeType return the "TextNode" type.
+func (t *text) NodeType() NodeType {
+	return TextNode
+}
+
+/* - Methods */
+
+// CloneNode return a clone of the Text node.
+func (t *text) CloneNode(_ bool) Node {
+	clone := createTextNode(t.data)
+	clone.SetOwnerDocument(t.document)
+
+	return clone
+}
+
+// IsEqualNode return wether or not two Text are equal.
+func (t *text) IsEqualNode(other Node) bool {
+	if other == nil || other.NodeType() != TextNode {
+		return false
 	}
+
+	return t.Data() == other.(Text).Data()
 }
 
 /*****************************************************
@@ -62,22 +97,27 @@ func (t *text) WholeText() string {
 // https://developer.mozilla.org/en-US/docs/Web/API/Text/splitText
 // https://dom.spec.whatwg.org/#dom-text-splittext
 func (t *text) SplitText(offset uint) (Text, e.Exception) {
-	var count uint = uint(t.Length()) - offset
-
 	// If offset is greater than length
-	if count < 0 {
-		return nil, e.RangeError("The offset %v is larger than the Text node's length.", string(offset))
+	if offset > uint(t.Length()) {
+		return nil, e.RangeError("The offset %v is larger than the Text node's length.", offset)
 	}
 
+	var count uint = uint(t.Length()) - offset
+
 	// Substring is data of the new node
 	newTextData := t.SubstringData(offset, count)
 	// Creating new node
 	newText := createTextNode(newTextData)
 	newText.SetOwnerDocument(t.OwnerDocument())
 
+	// The live ranges are only updated if the new node
+	// was inserted
 	if parent := t.ParentNode(); parent != nil {
 		parent.InsertBefore(newText, t.NextSibling())
 
+		for lr := range liveRangesOf(t) {
+			lr.textSplit(t, newText, int(offset))
+		}
 	}
 
 	// Deleting data of the current Text node
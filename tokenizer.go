@@ -0,0 +1,386 @@
+package gom
+
+import (
+	"html"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	e "github.com/negrel/gom/exception"
+)
+
+// tokenType is the type of a GOML token.
+type tokenType uint8
+
+// Token type list
+const (
+	textToken tokenType = iota
+	startTagToken
+	endTagToken
+	selfClosingTagToken
+	commentToken
+	doctypeToken
+)
+
+// position is a location in the GOML source.
+type position struct {
+	line   int
+	column int
+}
+
+// token is a lexical unit of a GOML source.
+type token struct {
+	typ   tokenType
+	data  string
+	attrs []tokenAttr
+	pos   position
+}
+
+// tokenAttr is an attribute of a start tag token.
+type tokenAttr struct {
+	name  string
+	value string
+}
+
+// rawTextElements contains the elements whose content
+// is not parsed as GOML.
+var rawTextElements = map[string]bool{
+	"script": true,
+	"style":  true,
+}
+
+// tokenizer split a GOML source into tokens.
+type tokenizer struct {
+	src    string
+	offset int
+	pos    position
+	// rawTag is the name of the raw text element
+	// whose content must be read next.
+	rawTag string
+}
+
+func newTokenizer(src string) *tokenizer {
+	return &tokenizer{
+		src: src,
+		pos: position{line: 1, column: 1},
+	}
+}
+
+// errorf return a ParseError located at the given position.
+func (t *tokenizer) errorf(pos position, format string, args ...interface{}) *ParseError {
+	return &ParseError{
+		Exception: e.New(e.SyntaxError, format, args...),
+		Line:      pos.line,
+		Column:    pos.column,
+	}
+}
+
+func (t *tokenizer) eof() bool {
+	return t.offset >= len(t.src)
+}
+
+// peek return the next rune without consuming it.
+func (t *tokenizer) peek() rune {
+	r, _ := utf8.DecodeRuneInString(t.src[t.offset:])
+	return r
+}
+
+// hasPrefix report whether the unread source start
+// with prefix.
+func (t *tokenizer) hasPrefix(prefix string) bool {
+	return strings.HasPrefix(t.src[t.offset:], prefix)
+}
+
+// advance consume n bytes of the source.
+func (t *tokenizer) advance(n int) {
+	end := t.offset + n
+
+	for t.offset < end {
+		r, size := utf8.DecodeRuneInString(t.src[t.offset:])
+		t.offset += size
+
+		if r == '\n' {
+			t.pos.line++
+			t.pos.column = 1
+		} else {
+			t.pos.column++
+		}
+	}
+}
+
+// skipSpaces consume all the whitespaces.
+func (t *tokenizer) skipSpaces() {
+	for !t.eof() && unicode.IsSpace(t.peek()) {
+		t.advance(utf8.RuneLen(t.peek()))
+	}
+}
+
+// readName consume and return a tag or attribute name.
+func (t *tokenizer) readName() string {
+	start := t.offset
+
+	for !t.eof() {
+		r := t.peek()
+		if unicode.IsSpace(r) || strings.ContainsRune("/>=\"'<", r) {
+			break
+		}
+		t.advance(utf8.RuneLen(r))
+	}
+
+	return strings.ToLower(t.src[start:t.offset])
+}
+
+// next return the next token of the source or nil
+// when the end of the source is reached.
+func (t *tokenizer) next() (*token, *ParseError) {
+	if t.eof() {
+		return nil, nil
+	}
+
+	if t.rawTag != "" {
+		return t.readRawText()
+	}
+
+	if t.peek() == '<' {
+		switch {
+		case t.hasPrefix("<!--"):
+			return t.readComment()
+
+		case t.hasPrefix("<!"):
+			return t.readDoctype()
+
+		case t.hasPrefix("</"):
+			return t.readEndTag()
+		}
+
+		// A '<' not followed by a tag name is a text
+		if next, _ := utf8.DecodeRuneInString(t.src[t.offset+1:]); unicode.IsLetter(next) {
+			return t.readStartTag()
+		}
+	}
+
+	return t.readText(), nil
+}
+
+// readText consume a text token.
+func (t *tokenizer) readText() *token {
+	pos := t.pos
+	start := t.offset
+
+	// The first rune may be a '<' that doesn't start a tag
+	t.advance(utf8.RuneLen(t.peek()))
+
+	if i := strings.IndexByte(t.src[t.offset:], '<'); i != -1 {
+		t.advance(i)
+	} else {
+		t.advance(len(t.src) - t.offset)
+	}
+
+	return &token{
+		typ:  textToken,
+		data: html.UnescapeString(t.src[start:t.offset]),
+		pos:  pos,
+	}
+}
+
+// readRawText consume the content of a raw text element.
+func (t *tokenizer) readRawText() (*token, *ParseError) {
+	pos := t.pos
+	start := t.offset
+	end := strings.Index(strings.ToLower(t.src[t.offset:]), "</"+t.rawTag)
+
+	if end == -1 {
+		return nil, t.errorf(pos, "unclosed <%v> raw text", t.rawTag)
+	}
+
+	t.advance(end)
+	t.rawTag = ""
+
+	if end == 0 {
+		return t.next()
+	}
+
+	return &token{
+		typ:  textToken,
+		data: t.src[start:t.offset],
+		pos:  pos,
+	}, nil
+}
+
+// readComment consume a comment token.
+func (t *tokenizer) readComment() (*token, *ParseError) {
+	pos := t.pos
+	t.advance(len("<!--"))
+
+	end := strings.Index(t.src[t.offset:], "-->")
+	if end == -1 {
+		return nil, t.errorf(pos, "unclosed comment")
+	}
+
+	data := t.src[t.offset : t.offset+end]
+	t.advance(end + len("-->"))
+
+	return &token{
+		typ:  commentToken,
+		data: data,
+		pos:  pos,
+	}, nil
+}
+
+// readDoctype consume a doctype token.
+func (t *tokenizer) readDoctype() (*token, *ParseError) {
+	pos := t.pos
+	t.advance(len("<!"))
+
+	if !strings.EqualFold(t.readName(), "doctype") {
+		return nil, t.errorf(pos, "unexpected markup declaration, expected <!DOCTYPE or <!--")
+	}
+
+	t.skipSpaces()
+	name := t.readName()
+	if name == "" {
+		return nil, t.errorf(t.pos, "missing doctype name")
+	}
+
+	end := strings.IndexByte(t.src[t.offset:], '>')
+	if end == -1 {
+		return nil, t.errorf(pos, "unclosed doctype")
+	}
+	t.advance(end + 1)
+
+	return &token{
+		typ:  doctypeToken,
+		data: name,
+		pos:  pos,
+	}, nil
+}
+
+// readEndTag consume an end tag token.
+func (t *tokenizer) readEndTag() (*token, *ParseError) {
+	pos := t.pos
+	t.advance(len("</"))
+
+	name := t.readName()
+	if name == "" {
+		return nil, t.errorf(pos, "missing end tag name")
+	}
+
+	t.skipSpaces()
+	if t.eof() || t.peek() != '>' {
+		return nil, t.errorf(t.pos, "unexpected character in </%v> end tag", name)
+	}
+	t.advance(1)
+
+	return &token{
+		typ:  endTagToken,
+		data: name,
+		pos:  pos,
+	}, nil
+}
+
+// readStartTag consume a start tag token and its
+// attributes.
+func (t *tokenizer) readStartTag() (*token, *ParseError) {
+	tok := &token{
+		typ: startTagToken,
+		pos: t.pos,
+	}
+	t.advance(len("<"))
+	tok.data = t.readName()
+
+	for {
+		t.skipSpaces()
+
+		if t.eof() {
+			return nil, t.errorf(tok.pos, "unclosed <%v> start tag", tok.data)
+		}
+
+		switch {
+		case t.hasPrefix("/>"):
+			t.advance(len("/>"))
+			tok.typ = selfClosingTagToken
+			return tok, nil
+
+		case t.hasPrefix(">"):
+			t.advance(len(">"))
+			if rawTextElements[tok.data] {
+				t.rawTag = tok.data
+			}
+			return tok, nil
+		}
+
+		attr, err := t.readAttr()
+		if err != nil {
+			return nil, err
+		}
+
+		if !tok.hasAttr(attr.name) {
+			tok.attrs = append(tok.attrs, attr)
+		}
+	}
+}
+
+// readAttr consume an attribute of a start tag.
+func (t *tokenizer) readAttr() (tokenAttr, *ParseError) {
+	pos := t.pos
+	attr := tokenAttr{name: t.readName()}
+
+	if attr.name == "" {
+		return attr, t.errorf(pos, "unexpected character %q in start tag", t.peek())
+	}
+
+	t.skipSpaces()
+	// Attribute without value
+	if t.eof() || t.peek() != '=' {
+		return attr, nil
+	}
+	t.advance(len("="))
+	t.skipSpaces()
+
+	if t.eof() {
+		return attr, t.errorf(pos, "missing value of %v attribute", attr.name)
+	}
+
+	// Quoted value
+	if quote := t.peek(); quote == '"' || quote == '\'' {
+		t.advance(1)
+
+		end := strings.IndexRune(t.src[t.offset:], quote)
+		if end == -1 {
+			return attr, t.errorf(pos, "unclosed value of %v attribute", attr.name)
+		}
+
+		attr.value = html.UnescapeString(t.src[t.offset : t.offset+end])
+		t.advance(end + 1)
+
+		return attr, nil
+	}
+
+	// Unquoted value
+	start := t.offset
+	for !t.eof() {
+		r := t.peek()
+		if unicode.IsSpace(r) || r == '>' || t.hasPrefix("/>") {
+			break
+		}
+		if strings.ContainsRune("\"'<=`", r) {
+			return attr, t.errorf(t.pos, "unexpected character %q in unquoted value of %v attribute", r, attr.name)
+		}
+		t.advance(utf8.RuneLen(r))
+	}
+	attr.value = html.UnescapeString(t.src[start:t.offset])
+
+	return attr, nil
+}
+
+// hasAttr report whether the token already has the
+// given attribute.
+func (tok *token) hasAttr(name string) bool {
+	for _, attr := range tok.attrs {
+		if attr.name == name {
+			return true
+		}
+	}
+
+	return false
+}
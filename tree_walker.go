@@ -0,0 +1,266 @@
+package gom
+
+// TreeWalker object represents the nodes of a document
+// subtree and a position within them.
+// https://developer.mozilla.org/en-US/docs/Web/API/TreeWalker
+// https://dom.spec.whatwg.org/#interface-treewalker
+type TreeWalker interface {
+	/* GETTERS & SETTERS (props) */
+	CurrentNode() Node
+	Filter() NodeFilter
+	Root() Node
+	SetCurrentNode(Node)
+	WhatToShow() uint32
+	/* METHODS */
+	FirstChild() Node
+	LastChild() Node
+	NextNode() Node
+	NextSibling() Node
+	ParentNode() Node
+	PreviousNode() Node
+	PreviousSibling() Node
+}
+
+var _ TreeWalker = &treeWalker{}
+
+type treeWalker struct {
+	*traversal
+	current Node
+}
+
+func newTreeWalker(root Node, whatToShow uint32, filter NodeFilter) TreeWalker {
+	return &treeWalker{
+		traversal: &traversal{
+			root:       root,
+			whatToShow: whatToShow,
+			filter:     filter,
+		},
+		current: root,
+	}
+}
+
+// traverseChildren move to the first or last visible
+// child of the current node.
+// https://dom.spec.whatwg.org/#concept-traverse-children
+func (tw *treeWalker) traverseChildren(first bool) Node {
+	child, sibling := Node.LastChild, Node.PreviousSibling
+	if first {
+		child, sibling = Node.FirstChild, Node.NextSibling
+	}
+
+	node := child(tw.current)
+	for node != nil {
+		switch tw.filterNode(node) {
+		case FilterAccept:
+			tw.current = node
+			return node
+
+		case FilterSkip:
+			if c := child(node); c != nil {
+				node = c
+				continue
+			}
+		}
+
+		for node != nil {
+			if s := sibling(node); s != nil {
+				node = s
+				break
+			}
+
+			parent := node.ParentNode()
+			if parent == nil || parent == tw.root || parent == tw.current {
+				return nil
+			}
+			node = parent
+		}
+	}
+
+	return nil
+}
+
+// traverseSiblings move to the next or previous visible
+// sibling of the current node.
+// https://dom.spec.whatwg.org/#concept-traverse-siblings
+func (tw *treeWalker) traverseSiblings(next bool) Node {
+	child, sibling := Node.LastChild, Node.PreviousSibling
+	if next {
+		child, sibling = Node.FirstChild, Node.NextSibling
+	}
+
+	node := tw.current
+	if node == tw.root {
+		return nil
+	}
+
+	for {
+		s := sibling(node)
+		for s != nil {
+			node = s
+			result := tw.filterNode(node)
+			if result == FilterAccept {
+				tw.current = node
+				return node
+			}
+
+			s = child(node)
+			if result == FilterReject || s == nil {
+				s = sibling(node)
+			}
+		}
+
+		node = node.ParentNode()
+		if node == nil || node == tw.root || tw.filterNode(node) == FilterAccept {
+			return nil
+		}
+	}
+}
+
+/*****************************************************
+ **************** Getters & Setters ******************
+ *****************************************************/
+// ANCHOR Getters & Setters
+
+// CurrentNode return the node on which the TreeWalker
+// is positioned.
+// https://developer.mozilla.org/en-US/docs/Web/API/TreeWalker/currentNode
+func (tw *treeWalker) CurrentNode() Node {
+	return tw.current
+}
+
+// SetCurrentNode set the node on which the TreeWalker
+// is positioned.
+// https://developer.mozilla.org/en-US/docs/Web/API/TreeWalker/currentNode
+func (tw *treeWalker) SetCurrentNode(node Node) {
+	tw.current = node
+}
+
+/*****************************************************
+ ********************* Methods ***********************
+ *****************************************************/
+// ANCHOR Methods
+
+// FirstChild moves the current node to the first visible
+// child of the current node and returns it or nil if there
+// is none.
+// https://developer.mozilla.org/en-US/docs/Web/API/TreeWalker/firstChild
+func (tw *treeWalker) FirstChild() Node {
+	return tw.traverseChildren(true)
+}
+
+// LastChild moves the current node to the last visible
+// child of the current node and returns it or nil if there
+// is none.
+// https://developer.mozilla.org/en-US/docs/Web/API/TreeWalker/lastChild
+func (tw *treeWalker) LastChild() Node {
+	return tw.traverseChildren(false)
+}
+
+// NextNode moves the current node to the next visible node
+// in tree order and returns it or nil if there is none.
+// https://developer.mozilla.org/en-US/docs/Web/API/TreeWalker/nextNode
+func (tw *treeWalker) NextNode() Node {
+	node := tw.current
+	result := FilterAccept
+
+	for {
+		for result != FilterReject && node.HasChildNodes() {
+			node = node.FirstChild()
+			result = tw.filterNode(node)
+			if result == FilterAccept {
+				tw.current = node
+				return node
+			}
+		}
+
+		// Following node that isn't a descendant of node
+		var sibling Node
+		for temporary := node; temporary != nil && sibling == nil; temporary = temporary.ParentNode() {
+			if temporary == tw.root {
+				return nil
+			}
+			sibling = temporary.NextSibling()
+		}
+		if sibling == nil {
+			return nil
+		}
+
+		node = sibling
+		result = tw.filterNode(node)
+		if result == FilterAccept {
+			tw.current = node
+			return node
+		}
+	}
+}
+
+// NextSibling moves the current node to its next visible
+// sibling and returns it or nil if there is none.
+// https://developer.mozilla.org/en-US/docs/Web/API/TreeWalker/nextSibling
+func (tw *treeWalker) NextSibling() Node {
+	return tw.traverseSiblings(true)
+}
+
+// ParentNode moves the current node to the first visible
+// ancestor of the current node and returns it or nil if
+// there is none.
+// https://developer.mozilla.org/en-US/docs/Web/API/TreeWalker/parentNode
+func (tw *treeWalker) ParentNode() Node {
+	node := tw.current
+
+	for node != nil && node != tw.root {
+		node = node.ParentNode()
+
+		if node != nil && tw.filterNode(node) == FilterAccept {
+			tw.current = node
+			return node
+		}
+	}
+
+	return nil
+}
+
+// PreviousNode moves the current node to the previous
+// visible node in tree order and returns it or nil if
+// there is none.
+// https://developer.mozilla.org/en-US/docs/Web/API/TreeWalker/previousNode
+func (tw *treeWalker) PreviousNode() Node {
+	node := tw.current
+
+	for node != tw.root {
+		for sibling := node.PreviousSibling(); sibling != nil; sibling = node.PreviousSibling() {
+			node = sibling
+			result := tw.filterNode(node)
+
+			// Last visible descendant
+			for result != FilterReject && node.HasChildNodes() {
+				node = node.LastChild()
+				result = tw.filterNode(node)
+			}
+
+			if result == FilterAccept {
+				tw.current = node
+				return node
+			}
+		}
+
+		if node == tw.root || node.ParentNode() == nil {
+			return nil
+		}
+
+		node = node.ParentNode()
+		if tw.filterNode(node) == FilterAccept {
+			tw.current = node
+			return node
+		}
+	}
+
+	return nil
+}
+
+// PreviousSibling moves the current node to its previous
+// visible sibling and returns it or nil if there is none.
+// https://developer.mozilla.org/en-US/docs/Web/API/TreeWalker/previousSibling
+func (tw *treeWalker) PreviousSibling() Node {
+	return tw.traverseSiblings(false)
+}
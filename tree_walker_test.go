@@ -0,0 +1,155 @@
+package gom
+
+import (
+	"testing"
+)
+
+// nodeNames return the name of the nodes, the tag name
+// of elements and the data of text nodes.
+func nodeNames(nodes ...Node) []string {
+	names := make([]string, len(nodes))
+
+	for i, node := range nodes {
+		switch n := node.(type) {
+		case Element:
+			names[i] = n.TagName()
+		case Text:
+			names[i] = n.Data()
+		case nil:
+			names[i] = "<nil>"
+		default:
+			names[i] = n.NodeName()
+		}
+	}
+
+	return names
+}
+
+const traversalTestSrc = `<div><p>a<b>b</b></p><ul><li>c</li><li class="skip"><i>d</i></li></ul><span>e</span></div>`
+
+func TestTreeWalkerNextPreviousNode(t *testing.T) {
+	doc := parseTestDocument(t, traversalTestSrc)
+	walker := doc.CreateTreeWalker(doc.DocumentElement(), ShowElement, nil)
+
+	var forward []Node
+	for node := walker.NextNode(); node != nil; node = walker.NextNode() {
+		forward = append(forward, node)
+	}
+
+	expected := []string{"p", "b", "ul", "li", "li", "i", "span"}
+	if names := nodeNames(forward...); !equalStrings(names, expected) {
+		t.Logf("NextNode must traverse the elements in tree order, got %v.", names)
+		t.Fail()
+	}
+
+	var backward []Node
+	for node := walker.PreviousNode(); node != nil; node = walker.PreviousNode() {
+		backward = append(backward, node)
+	}
+
+	expected = []string{"i", "li", "li", "ul", "b", "p", "div"}
+	if names := nodeNames(backward...); !equalStrings(names, expected) {
+		t.Logf("PreviousNode must traverse the elements in reverse tree order, got %v.", names)
+		t.Fail()
+	}
+
+	if !walker.CurrentNode().IsSameNode(doc.DocumentElement()) {
+		t.Log("Current node must be the root after a backward traversal.")
+		t.Fail()
+	}
+}
+
+func TestTreeWalkerFilter(t *testing.T) {
+	doc := parseTestDocument(t, traversalTestSrc)
+
+	filter := func(result int) NodeFilter {
+		return func(node Node) int {
+			if node.(Element).ClassName() == "skip" {
+				return result
+			}
+
+			return FilterAccept
+		}
+	}
+
+	walker := doc.CreateTreeWalker(doc.DocumentElement(), ShowElement, filter(FilterSkip))
+	var nodes []Node
+	for node := walker.NextNode(); node != nil; node = walker.NextNode() {
+		nodes = append(nodes, node)
+	}
+
+	if names := nodeNames(nodes...); !equalStrings(names, []string{"p", "b", "ul", "li", "i", "span"}) {
+		t.Logf("Skipped node descendants must be traversed, got %v.", names)
+		t.Fail()
+	}
+
+	walker = doc.CreateTreeWalker(doc.DocumentElement(), ShowElement, filter(FilterReject))
+	nodes = nil
+	for node := walker.NextNode(); node != nil; node = walker.NextNode() {
+		nodes = append(nodes, node)
+	}
+
+	if names := nodeNames(nodes...); !equalStrings(names, []string{"p", "b", "ul", "li", "span"}) {
+		t.Logf("Rejected node descendants must not be traversed, got %v.", names)
+		t.Fail()
+	}
+
+	// Skipped node children are visible children
+	ul := doc.DocumentElement().Children().Item(1)
+	walker = doc.CreateTreeWalker(doc.DocumentElement(), ShowElement, filter(FilterSkip))
+	walker.SetCurrentNode(ul)
+
+	if last := walker.LastChild(); last == nil || last.(Element).TagName() != "i" {
+		t.Logf("LastChild must return the last visible child, got %v.", nodeNames(last))
+		t.Fail()
+	}
+
+	if parent := walker.ParentNode(); parent == nil || !parent.IsSameNode(ul) {
+		t.Logf("ParentNode must skip the skipped ancestors, got %v.", nodeNames(parent))
+		t.Fail()
+	}
+}
+
+func TestTreeWalkerSiblings(t *testing.T) {
+	doc := parseTestDocument(t, traversalTestSrc)
+	div := doc.DocumentElement()
+	walker := doc.CreateTreeWalker(div, ShowAll, nil)
+
+	if first := walker.FirstChild(); first == nil || first.(Element).TagName() != "p" {
+		t.Fatalf("FirstChild must return the first child, got %v.", nodeNames(first))
+	}
+
+	var siblings []Node
+	for node := walker.NextSibling(); node != nil; node = walker.NextSibling() {
+		siblings = append(siblings, node)
+	}
+
+	if names := nodeNames(siblings...); !equalStrings(names, []string{"ul", "span"}) {
+		t.Logf("NextSibling must return the following siblings, got %v.", names)
+		t.Fail()
+	}
+
+	if previous := walker.PreviousSibling(); previous == nil || previous.(Element).TagName() != "ul" {
+		t.Logf("PreviousSibling must return the previous sibling, got %v.", nodeNames(previous))
+		t.Fail()
+	}
+
+	// Text nodes
+	walker = doc.CreateTreeWalker(div, ShowText, nil)
+	var texts []Node
+	for node := walker.NextNode(); node != nil; node = walker.NextNode() {
+		texts = append(texts, node)
+	}
+
+	if names := nodeNames(texts...); !equalStrings(names, []string{"a", "b", "c", "d", "e"}) {
+		t.Logf("ShowText must only show the text nodes, got %v.", names)
+		t.Fail()
+	}
+
+	// Root siblings are not visible
+	walker.SetCurrentNode(div)
+	if walker.NextSibling() != nil || walker.ParentNode() != nil {
+		t.Log("Nodes outside of the root must not be visible.")
+		t.Fail()
+	}
+}